@@ -1,2 +1,22 @@
 // Package ast provides definitions about Lox language.
 package ast
+
+// Span is the range of a node in the source code, in byte offsets from the beginning of the source. Start is
+// inclusive and End is exclusive.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Node is implemented by every node of the AST. Nodes embed a [Span] to implement it.
+type Node interface {
+	Location() Span
+}
+
+// Location returns the span itself, so that every node embedding a [Span] implements [Node].
+func (s Span) Location() Span { return s }
+
+// To returns a span from the start of s to the end of other.
+func (s Span) To(other Span) Span {
+	return Span{Start: s.Start, End: other.End}
+}
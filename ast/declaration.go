@@ -1,6 +1,7 @@
 package ast
 
 type Declaration interface {
+	Node
 	Accept(DeclarationVisitor)
 }
 
@@ -12,22 +13,26 @@ type DeclarationVisitor interface {
 }
 
 type StatementDeclaration struct {
+	Span
 	Statement Statement
 }
 
 type ClassDeclaration struct {
+	Span
 	Name      Identifier
 	Baseclass *Identifier
-	Methods   []FunDeclaration
+	Methods   []*FunDeclaration
 }
 
 type FunDeclaration struct {
+	Span
 	Name       Identifier
 	Parameters []Identifier
 	Body       *BlockStatement
 }
 
 type VarDeclaration struct {
+	Span
 	Name        Identifier
 	Initializer Expression
 }
@@ -0,0 +1,76 @@
+package ast
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const dumpIndent = "  "
+
+// Dump writes a human-readable tree of node (usually a []Declaration) into w. It is mainly used for debugging the
+// parser, so the format is not stable.
+func Dump(w io.Writer, node any) error {
+	builder := new(strings.Builder)
+	dumpValue(builder, reflect.ValueOf(node), 0)
+	builder.WriteByte('\n')
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func dumpValue(builder *strings.Builder, value reflect.Value, depth int) {
+	if !value.IsValid() {
+		builder.WriteString("nil")
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			builder.WriteString("nil")
+			return
+		}
+		if value.Kind() == reflect.Pointer {
+			builder.WriteByte('&')
+		}
+		dumpValue(builder, value.Elem(), depth)
+	case reflect.Slice:
+		if value.Len() == 0 {
+			builder.WriteString("[]")
+			return
+		}
+		builder.WriteString("[\n")
+		for i := range value.Len() {
+			builder.WriteString(strings.Repeat(dumpIndent, depth+1))
+			dumpValue(builder, value.Index(i), depth+1)
+			builder.WriteString(",\n")
+		}
+		builder.WriteString(strings.Repeat(dumpIndent, depth))
+		builder.WriteByte(']')
+	case reflect.Struct:
+		if span, isSpan := value.Interface().(Span); isSpan {
+			_, _ = fmt.Fprintf(builder, "%d..%d", span.Start, span.End)
+			return
+		}
+		builder.WriteString(value.Type().Name())
+		if value.NumField() == 0 {
+			builder.WriteString("{}")
+			return
+		}
+		builder.WriteString("{\n")
+		for i := range value.NumField() {
+			builder.WriteString(strings.Repeat(dumpIndent, depth+1))
+			builder.WriteString(value.Type().Field(i).Name)
+			builder.WriteString(": ")
+			dumpValue(builder, value.Field(i), depth+1)
+			builder.WriteString(",\n")
+		}
+		builder.WriteString(strings.Repeat(dumpIndent, depth))
+		builder.WriteByte('}')
+	case reflect.String:
+		_, _ = fmt.Fprintf(builder, "%s(%q)", value.Type().Name(), value.String())
+	default:
+		_, _ = fmt.Fprintf(builder, "%s(%v)", value.Type().Name(), value.Interface())
+	}
+}
@@ -24,6 +24,7 @@ type UnaryOperator byte
 type BinaryOperator byte
 
 type Expression interface {
+	Node
 	Accept(visitor ExpressionVisitor)
 }
 
@@ -39,45 +40,74 @@ type ExpressionVisitor interface {
 	VisitThis(t This)
 	VisitNumberLiteral(n NumberLiteral)
 	VisitStringLiteral(s StringLiteral)
-	VisitIdentifier(i Identifier)
+	VisitIdentifier(i *Identifier)
 	VisitSuper(s Super)
 }
 
 type AssignmentExpression struct {
+	Span
 	Target Expression
 	Value  Expression
 }
 
 type BinaryExpression struct {
+	Span
 	Left     Expression
 	Right    Expression
 	Operator BinaryOperator
 }
 
 type UnaryExpression struct {
+	Span
 	Operand  Expression
 	Operator UnaryOperator
 }
 
 type InvocationExpression struct {
+	Span
 	Callee    Expression
 	Arguments []Expression
 }
 
 type PropertyAccessExpression struct {
+	Span
 	Target   Expression
 	Property Identifier
 }
 
 // Primary expressions
 
-type BooleanLiteral bool
-type Nil struct{}
-type This struct{}
-type NumberLiteral float64
-type StringLiteral string
-type Identifier string
-type Super struct{}
+type BooleanLiteral struct {
+	Span
+	Value bool
+}
+
+type Nil struct {
+	Span
+}
+
+type This struct {
+	Span
+}
+
+type NumberLiteral struct {
+	Span
+	Value float64
+}
+
+type StringLiteral struct {
+	Span
+	Value string
+}
+
+type Identifier struct {
+	Span
+	Name string
+}
+
+type Super struct {
+	Span
+}
 
 func (a *AssignmentExpression) Accept(visitor ExpressionVisitor)     { visitor.VisitAssignment(a) }
 func (b *BinaryExpression) Accept(visitor ExpressionVisitor)         { visitor.VisitBinary(b) }
@@ -89,5 +119,5 @@ func (n Nil) Accept(visitor ExpressionVisitor)                       { visitor.V
 func (t This) Accept(visitor ExpressionVisitor)                      { visitor.VisitThis(t) }
 func (s StringLiteral) Accept(visitor ExpressionVisitor)             { visitor.VisitStringLiteral(s) }
 func (n NumberLiteral) Accept(visitor ExpressionVisitor)             { visitor.VisitNumberLiteral(n) }
-func (i Identifier) Accept(visitor ExpressionVisitor)                { visitor.VisitIdentifier(i) }
+func (i *Identifier) Accept(visitor ExpressionVisitor)               { visitor.VisitIdentifier(i) }
 func (s Super) Accept(visitor ExpressionVisitor)                     { visitor.VisitSuper(s) }
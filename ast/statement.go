@@ -1,6 +1,7 @@
 package ast
 
 type Statement interface {
+	Node
 	Accept(StatementVisitor)
 }
 
@@ -15,37 +16,51 @@ type StatementVisitor interface {
 }
 
 type ExpressionStatement struct {
+	Span
 	Expression Expression
 }
 
+// ForStatement is a C-style for loop. Every clause is optional, and nil when omitted, except the body.
 type ForStatement struct {
-	ExpressionInitializer Expression
-	VarInitializer        *VarDeclaration
-	Condition             Expression
-	Increment             Expression
-	Body                  Statement
+	Span
+	Initializer ForInitializer
+	Condition   Expression
+	Increment   Expression
+	Body        Statement
+}
+
+// ForInitializer is the initializer clause of a [ForStatement], which is either a *[VarDeclaration] or an
+// *[ExpressionStatement]. Use a type switch to tell them apart.
+type ForInitializer interface {
+	Node
+	forInitializer()
 }
 
 type IfStatement struct {
+	Span
 	Condition Expression
 	Then      Statement
 	Otherwise Statement
 }
 
 type PrintStatement struct {
+	Span
 	Expression Expression
 }
 
 type ReturnStatement struct {
+	Span
 	Expression Expression
 }
 
 type WhileStatement struct {
+	Span
 	Condition Expression
 	Body      Statement
 }
 
 type BlockStatement struct {
+	Span
 	Declarations []Declaration
 }
 
@@ -56,3 +71,6 @@ func (p *PrintStatement) Accept(visitor StatementVisitor)       { visitor.VisitP
 func (r *ReturnStatement) Accept(visitor StatementVisitor)      { visitor.VisitReturn(r) }
 func (w *WhileStatement) Accept(visitor StatementVisitor)       { visitor.VisitWhile(w) }
 func (b *BlockStatement) Accept(visitor StatementVisitor)       { visitor.VisitBlock(b) }
+
+func (*VarDeclaration) forInitializer()      {}
+func (*ExpressionStatement) forInitializer() {}
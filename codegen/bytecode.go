@@ -1,5 +1,7 @@
 package codegen
 
+import "fmt"
+
 const (
 	Constant OperationCode = iota
 	Nil
@@ -30,6 +32,13 @@ const (
 	Invoke
 	Return
 	Print
+	Class
+	Inherit
+	Method
+	GetProperty
+	SetProperty
+	GetSuper
+	SuperInvoke
 	Impossible
 )
 
@@ -38,5 +47,103 @@ type OperationCode uint8
 type ConstantIndex uint16
 type GlobalIndex uint8
 type LocalOffset uint8
+type UpvalueIndex uint8
 type JumpOffset int16
 type CallPosition uint16
+
+// operandKind tells what operand follows an operation code, so that the bytecode can be emitted and decoded safely.
+type operandKind uint8
+
+const (
+	noOperand operandKind = iota
+	constantOperand
+	globalOperand
+	localOperand
+	upvalueOperand
+	captureOperand
+	jumpOperand
+	callOperand
+	invokeOperand
+)
+
+var operationNames = [...]string{
+	Constant:    "Constant",
+	Nil:         "Nil",
+	True:        "True",
+	False:       "False",
+	Fun:         "Fun",
+	Negate:      "Negate",
+	Not:         "Not",
+	Add:         "Add",
+	Subtract:    "Subtract",
+	Multiply:    "Multiply",
+	Divide:      "Divide",
+	Equal:       "Equal",
+	Greater:     "Greater",
+	Less:        "Less",
+	GetGlobal:   "GetGlobal",
+	SetGlobal:   "SetGlobal",
+	GetLocal:    "GetLocal",
+	SetLocal:    "SetLocal",
+	Pop:         "Pop",
+	Closure:     "Closure",
+	Capture:     "Capture",
+	GetUpvalue:  "GetUpvalue",
+	SetUpvalue:  "SetUpvalue",
+	JumpIfFalse: "JumpIfFalse",
+	Jump:        "Jump",
+	Call:        "Call",
+	Invoke:      "Invoke",
+	Return:      "Return",
+	Print:       "Print",
+	Class:       "Class",
+	Inherit:     "Inherit",
+	Method:      "Method",
+	GetProperty: "GetProperty",
+	SetProperty: "SetProperty",
+	GetSuper:    "GetSuper",
+	SuperInvoke: "SuperInvoke",
+}
+
+var operandKinds = map[OperationCode]operandKind{
+	Constant:    constantOperand,
+	Fun:         constantOperand,
+	Closure:     constantOperand,
+	Capture:     captureOperand,
+	GetUpvalue:  upvalueOperand,
+	SetUpvalue:  upvalueOperand,
+	GetGlobal:   globalOperand,
+	SetGlobal:   globalOperand,
+	GetLocal:    localOperand,
+	SetLocal:    localOperand,
+	JumpIfFalse: jumpOperand,
+	Jump:        jumpOperand,
+	Call:        callOperand,
+	Invoke:      invokeOperand,
+	Class:       constantOperand,
+	Method:      constantOperand,
+	GetProperty: constantOperand,
+	SetProperty: constantOperand,
+	GetSuper:    constantOperand,
+	SuperInvoke: invokeOperand,
+}
+
+// operandWidths are the encoded sizes of operands in bytes.
+var operandWidths = [...]int{
+	noOperand:       0,
+	constantOperand: 2,
+	globalOperand:   1,
+	localOperand:    1,
+	upvalueOperand:  1,
+	captureOperand:  2,
+	jumpOperand:     2,
+	callOperand:     2,
+	invokeOperand:   4,
+}
+
+func (op OperationCode) String() string {
+	if int(op) < len(operationNames) && operationNames[op] != "" {
+		return operationNames[op]
+	}
+	return fmt.Sprintf("OperationCode(%d)", op)
+}
@@ -0,0 +1,163 @@
+package codegen
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Chunk is a sequence of Mussel Lox Bytecode with the constants it references and the source lines it comes from.
+//
+// Instructions are appended by the typed emitters, which make sure every operation code is followed by operands of
+// the right type. Operands are encoded in little endian.
+type Chunk struct {
+	code      []byte
+	constants []any
+	lines     []lineRun
+
+	// constantIndices deduplicates numbers and strings in the constant pool.
+	constantIndices map[any]ConstantIndex
+	line            int
+}
+
+// lineRun is an entry of the run-length encoded line table: count consecutive bytes of code come from line.
+type lineRun struct {
+	line  int
+	count int
+}
+
+// NewChunk creates an empty [Chunk].
+func NewChunk() *Chunk {
+	return &Chunk{constantIndices: make(map[any]ConstantIndex)}
+}
+
+// Code returns the encoded instructions.
+func (c *Chunk) Code() []byte { return c.code }
+
+// Len returns the length of code in bytes, which is also the offset of the next instruction.
+func (c *Chunk) Len() int { return len(c.code) }
+
+// Constants returns the constant pool. A constant is either a float64, a string or a *[Function].
+func (c *Chunk) Constants() []any { return c.constants }
+
+// AddConstant puts value into the constant pool and returns its index. Equal numbers and strings share one entry.
+func (c *Chunk) AddConstant(value any) (ConstantIndex, error) {
+	switch value.(type) {
+	case float64, string:
+		if index, exists := c.constantIndices[value]; exists {
+			return index, nil
+		}
+	case *Function:
+	default:
+		panic(fmt.Sprintf("unsupported constant of type %T", value))
+	}
+	// The constant count is encoded as u16, so the pool holds at most 65535 entries.
+	if len(c.constants) >= math.MaxUint16 {
+		return 0, fmt.Errorf("too many constants in one chunk, the limit is %d", math.MaxUint16)
+	}
+
+	index := ConstantIndex(len(c.constants))
+	c.constants = append(c.constants, value)
+	if _, isFunction := value.(*Function); !isFunction {
+		c.constantIndices[value] = index
+	}
+	return index, nil
+}
+
+// MarkLine sets the source line of instructions emitted afterwards.
+func (c *Chunk) MarkLine(line int) { c.line = line }
+
+// LineOf returns the source line of the instruction at offset, or -1 if the offset is out of the code.
+func (c *Chunk) LineOf(offset int) int {
+	if offset < 0 {
+		return -1
+	}
+	for _, run := range c.lines {
+		if offset < run.count {
+			return run.line
+		}
+		offset -= run.count
+	}
+	return -1
+}
+
+// Emit appends an instruction without operands.
+func (c *Chunk) Emit(op OperationCode) {
+	c.checkOperand(op, noOperand)
+	c.write(byte(op))
+}
+
+// EmitConstant appends an instruction taking a [ConstantIndex].
+func (c *Chunk) EmitConstant(op OperationCode, index ConstantIndex) {
+	c.checkOperand(op, constantOperand)
+	c.write(binary.LittleEndian.AppendUint16([]byte{byte(op)}, uint16(index))...)
+}
+
+// EmitGlobal appends an instruction taking a [GlobalIndex].
+func (c *Chunk) EmitGlobal(op OperationCode, index GlobalIndex) {
+	c.checkOperand(op, globalOperand)
+	c.write(byte(op), byte(index))
+}
+
+// EmitLocal appends an instruction taking a [LocalOffset].
+func (c *Chunk) EmitLocal(op OperationCode, offset LocalOffset) {
+	c.checkOperand(op, localOperand)
+	c.write(byte(op), byte(offset))
+}
+
+// EmitUpvalue appends an instruction taking an [UpvalueIndex].
+func (c *Chunk) EmitUpvalue(op OperationCode, index UpvalueIndex) {
+	c.checkOperand(op, upvalueOperand)
+	c.write(byte(op), byte(index))
+}
+
+// EmitCapture appends a Capture instruction describing upvalue.
+func (c *Chunk) EmitCapture(upvalue Upvalue) {
+	isLocal := byte(0)
+	if upvalue.IsLocal {
+		isLocal = 1
+	}
+	c.write(byte(Capture), isLocal, byte(upvalue.Index))
+}
+
+// EmitCall appends a Call instruction.
+func (c *Chunk) EmitCall(position CallPosition) {
+	c.write(binary.LittleEndian.AppendUint16([]byte{byte(Call)}, uint16(position))...)
+}
+
+// EmitInvoke appends an instruction calling the method named by the constant at index with arguments.
+func (c *Chunk) EmitInvoke(op OperationCode, index ConstantIndex, arguments CallPosition) {
+	c.checkOperand(op, invokeOperand)
+	code := binary.LittleEndian.AppendUint16([]byte{byte(op)}, uint16(index))
+	c.write(binary.LittleEndian.AppendUint16(code, uint16(arguments))...)
+}
+
+// EmitJump appends a jump instruction with a placeholder [JumpOffset] and returns the offset of the operand, which
+// should be filled by [Chunk.PatchJump] later.
+//
+// Jump offsets are relative to the end of the jump instruction, so that a zero offset is a no-op.
+func (c *Chunk) EmitJump(op OperationCode) int {
+	c.checkOperand(op, jumpOperand)
+	c.write(byte(op), 0, 0)
+	return len(c.code) - 2
+}
+
+// PatchJump fills the operand of the jump instruction emitted by [Chunk.EmitJump].
+func (c *Chunk) PatchJump(position int, offset JumpOffset) {
+	binary.LittleEndian.PutUint16(c.code[position:], uint16(offset))
+}
+
+func (c *Chunk) checkOperand(op OperationCode, kind operandKind) {
+	if operandKinds[op] != kind {
+		panic(fmt.Sprintf("operation %s does not take operand of kind %d", op, kind))
+	}
+}
+
+func (c *Chunk) write(bytes ...byte) {
+	c.code = append(c.code, bytes...)
+	if n := len(c.lines); n > 0 && c.lines[n-1].line == c.line {
+		c.lines[n-1].count += len(bytes)
+		return
+	}
+	c.lines = append(c.lines, lineRun{line: c.line, count: len(bytes)})
+}
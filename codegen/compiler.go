@@ -0,0 +1,304 @@
+package codegen
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+	"github.com/mussel-lox/clam/resolver"
+)
+
+// Compiler walks the AST and emits Mussel Lox Bytecode into a [Function].
+//
+// Since the visitor interfaces cannot return errors, the Compiler records diagnostics and keeps going, so that as many
+// errors as possible are reported at once.
+type Compiler struct {
+	*frame
+	source      *diagnostic.Source
+	resolution  *resolver.Resolution
+	globals     map[string]GlobalIndex
+	diagnostics diagnostic.List
+}
+
+// Codes of the diagnostics reported by the Compiler, set by [diagnostic.Diagnostic.WithCode]. Most programs accepted by
+// the resolver only fail by exceeding the limits of the bytecode format.
+const (
+	codeLimitExceeded = "limit-exceeded"
+	codeInternal      = "internal-error"
+)
+
+const (
+	scriptFrame frameKind = iota
+	functionFrame
+	methodFrame
+	initializerFrame
+)
+
+// frameKind tells how a function is compiled: methods have this in the first local slot, and initializers always
+// return this.
+type frameKind int
+
+// frame is the compilation state of a function. Frames are chained to resolve upvalues of nested functions.
+type frame struct {
+	enclosing  *frame
+	kind       frameKind
+	function   *Function
+	chunk      *Chunk
+	locals     []local
+	scopeDepth int
+	// constantsExhausted is set once the constant pool of chunk is full, which is reported only once.
+	constantsExhausted bool
+}
+
+// local is a variable living on the stack. Its offset is the index in [frame.locals].
+type local struct {
+	name  string
+	depth int
+}
+
+// NewCompiler creates a [Compiler] ready to compile the top-level script parsed from source. The resolution from
+// package resolver tells where variable references live; with a nil resolution, variables are looked up by their names
+// only.
+func NewCompiler(source *diagnostic.Source, resolution *resolver.Resolution) *Compiler {
+	c := &Compiler{
+		source:     source,
+		resolution: resolution,
+		globals:    make(map[string]GlobalIndex),
+	}
+	c.beginFunction(NewFunction("", 0), scriptFrame)
+	return c
+}
+
+// Compile compiles a whole program parsed from source, which must have been checked by [resolver.Resolve], into the
+// top-level script [Function]. Semantic errors are reported by the resolver only; the Compiler reports the limits of
+// the bytecode.
+func Compile(program []ast.Declaration, source *diagnostic.Source, resolution *resolver.Resolution) (*Function, error) {
+	c := NewCompiler(source, resolution)
+	for _, decl := range program {
+		decl.Accept(c)
+	}
+	script := c.endFunction()
+	if err := c.Err(); err != nil {
+		return nil, err
+	}
+	return script, nil
+}
+
+// Err returns all diagnostics reported so far as a [diagnostic.List], or nil if there is none.
+func (c *Compiler) Err() error { return c.diagnostics.Err() }
+
+func (c *Compiler) report(node ast.Node, code, message string) {
+	c.diagnostics.Add(diagnostic.NewDiagnostic(message).WithCode(code).AtSpan(diagnostic.Span(node.Location())).Attach(c.source))
+}
+
+// mark sets the line of instructions emitted afterwards to the line where node starts.
+func (c *Compiler) mark(node ast.Node) {
+	c.chunk.MarkLine(c.lineOf(node.Location().Start))
+}
+
+// lineOf returns the 1-based line number of offset, or 0 if the source is unknown.
+func (c *Compiler) lineOf(offset int) int {
+	if c.source == nil {
+		return 0
+	}
+	return c.source.PositionOf(offset).Line + 1
+}
+
+// beginFunction starts compiling function. The first local slot is reserved for the callee itself, which is the
+// receiver this for methods.
+func (c *Compiler) beginFunction(function *Function, kind frameKind) {
+	receiver := ""
+	if kind == methodFrame || kind == initializerFrame {
+		receiver = "this"
+	}
+	c.frame = &frame{
+		enclosing: c.frame,
+		kind:      kind,
+		function:  function,
+		chunk:     function.Chunk,
+		locals:    []local{{name: receiver, depth: 0}},
+	}
+}
+
+// endFunction emits the implicit return and goes back to the enclosing function.
+func (c *Compiler) endFunction() *Function {
+	c.emitReturn()
+	function := c.function
+	c.frame = c.enclosing
+	return function
+}
+
+// emitReturn returns nil from the current function, or this from initializers.
+func (c *Compiler) emitReturn() {
+	if c.kind == initializerFrame {
+		c.chunk.EmitLocal(GetLocal, 0)
+	} else {
+		c.chunk.Emit(Nil)
+	}
+	c.chunk.Emit(Return)
+}
+
+func (c *Compiler) emitConstant(node ast.Node, value any) {
+	c.mark(node)
+	if index, ok := c.addConstant(node, value); ok {
+		c.chunk.EmitConstant(Constant, index)
+	}
+}
+
+// identifierConstant adds the name of a global, property or method into the constant pool.
+func (c *Compiler) identifierConstant(name ast.Identifier) (ConstantIndex, bool) {
+	return c.addConstant(name, name.Name)
+}
+
+func (c *Compiler) addConstant(node ast.Node, value any) (ConstantIndex, bool) {
+	index, err := c.chunk.AddConstant(value)
+	if err != nil {
+		if !c.constantsExhausted {
+			c.report(node, codeLimitExceeded, err.Error())
+			c.constantsExhausted = true
+		}
+		return 0, false
+	}
+	return index, true
+}
+
+func (c *Compiler) emitGlobal(op OperationCode, name ast.Identifier) {
+	index, exists := c.globals[name.Name]
+	if !exists {
+		if len(c.globals) > math.MaxUint8 {
+			c.report(name, codeLimitExceeded, fmt.Sprintf("too many global variables, cannot define %s", name.Name))
+			return
+		}
+		index = GlobalIndex(len(c.globals))
+		c.globals[name.Name] = index
+	}
+	c.chunk.EmitGlobal(op, index)
+}
+
+// emitReference emits the instruction accessing the variable referred by reference, according to its binding. The
+// resolution decides whether it is a local, an upvalue or a global; only the slot or the upvalue index is looked up by
+// name. Without a resolution, variables are looked up by name only.
+func (c *Compiler) emitReference(local, upvalue, global OperationCode, reference *ast.Identifier) {
+	if c.resolution == nil {
+		c.emitVariable(local, upvalue, global, *reference)
+		return
+	}
+	switch c.resolution.Lookup(reference).Kind {
+	case resolver.Local:
+		offset := c.frame.resolveLocal(reference.Name)
+		if offset < 0 {
+			c.report(reference, codeInternal, fmt.Sprintf("internal error: local variable %s is not in the current function", reference.Name))
+			return
+		}
+		c.chunk.EmitLocal(local, LocalOffset(offset))
+	case resolver.Upvalue:
+		index := c.resolveUpvalue(c.frame, *reference)
+		if index < 0 {
+			c.report(reference, codeInternal, fmt.Sprintf("internal error: variable %s is not in the enclosing functions", reference.Name))
+			return
+		}
+		c.chunk.EmitUpvalue(upvalue, UpvalueIndex(index))
+	default:
+		c.emitGlobal(global, *reference)
+	}
+}
+
+// emitVariable emits the instruction accessing the variable name, which is a local, an upvalue captured from enclosing
+// functions, or a global.
+func (c *Compiler) emitVariable(local, upvalue, global OperationCode, name ast.Identifier) {
+	if offset := c.frame.resolveLocal(name.Name); offset >= 0 {
+		c.chunk.EmitLocal(local, LocalOffset(offset))
+		return
+	}
+	if index := c.resolveUpvalue(c.frame, name); index >= 0 {
+		c.chunk.EmitUpvalue(upvalue, UpvalueIndex(index))
+		return
+	}
+	c.emitGlobal(global, name)
+}
+
+func (f *frame) resolveLocal(name string) int {
+	for i := len(f.locals) - 1; i >= 0; i-- {
+		if f.locals[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolveUpvalue finds name in the functions enclosing f, and adds the upvalues needed to capture it along the way.
+func (c *Compiler) resolveUpvalue(f *frame, name ast.Identifier) int {
+	if f.enclosing == nil {
+		return -1
+	}
+	if offset := f.enclosing.resolveLocal(name.Name); offset >= 0 {
+		return c.addUpvalue(f, name, Upvalue{IsLocal: true, Index: UpvalueIndex(offset)})
+	}
+	if index := c.resolveUpvalue(f.enclosing, name); index >= 0 {
+		return c.addUpvalue(f, name, Upvalue{IsLocal: false, Index: UpvalueIndex(index)})
+	}
+	return -1
+}
+
+func (c *Compiler) addUpvalue(f *frame, name ast.Identifier, upvalue Upvalue) int {
+	for i, existing := range f.function.Upvalues {
+		if existing == upvalue {
+			return i
+		}
+	}
+	// The upvalue count is encoded as u8, so a function captures at most 255 variables.
+	if len(f.function.Upvalues) >= math.MaxUint8 {
+		c.report(name, codeLimitExceeded, fmt.Sprintf("too many closure variables in function, the limit is %d", math.MaxUint8))
+		return 0
+	}
+	f.function.Upvalues = append(f.function.Upvalues, upvalue)
+	return len(f.function.Upvalues) - 1
+}
+
+// patchJump makes the jump whose operand is at position land on the current end of the code. The node jumped over is
+// blamed if the distance is too long.
+func (c *Compiler) patchJump(node ast.Node, position int) {
+	c.putJumpOffset(node, position, c.chunk.Len()-(position+2))
+}
+
+// emitLoop emits a backward jump to start, which is the beginning of the loop node.
+func (c *Compiler) emitLoop(node ast.Node, start int) {
+	position := c.chunk.EmitJump(Jump)
+	c.putJumpOffset(node, position, start-(position+2))
+}
+
+// putJumpOffset patches the jump at position with distance, reporting at node if the distance exceeds the limit of its
+// direction: 32767 bytes forward, or 32768 bytes backward.
+func (c *Compiler) putJumpOffset(node ast.Node, position, distance int) {
+	switch {
+	case distance > math.MaxInt16:
+		c.report(node, codeLimitExceeded, fmt.Sprintf("forward jump of %d bytes exceeds the limit of %d bytes", distance, math.MaxInt16))
+		return
+	case distance < math.MinInt16:
+		c.report(node, codeLimitExceeded, fmt.Sprintf("backward jump of %d bytes exceeds the limit of %d bytes", -distance, -math.MinInt16))
+		return
+	}
+	c.chunk.PatchJump(position, JumpOffset(distance))
+}
+
+func (c *Compiler) beginScope() { c.scopeDepth++ }
+
+// endScope pops all locals declared in the innermost scope. Mussel closes the upvalues capturing a local when its slot
+// is popped, so captured locals need no special treatment.
+func (c *Compiler) endScope() {
+	c.scopeDepth--
+	for len(c.locals) > 0 && c.locals[len(c.locals)-1].depth > c.scopeDepth {
+		c.chunk.Emit(Pop)
+		c.locals = c.locals[:len(c.locals)-1]
+	}
+}
+
+// declareLocal makes the value on the top of the stack a local variable of the current scope.
+func (c *Compiler) declareLocal(name ast.Identifier) {
+	if len(c.locals) > math.MaxUint8 {
+		c.report(name, codeLimitExceeded, fmt.Sprintf("too many local variables, cannot define %s", name.Name))
+		return
+	}
+	c.locals = append(c.locals, local{name: name.Name, depth: c.scopeDepth})
+}
@@ -0,0 +1,410 @@
+package codegen_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mussel-lox/clam/codegen"
+	"github.com/mussel-lox/clam/desugar"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+	"github.com/mussel-lox/clam/parser"
+	"github.com/mussel-lox/clam/resolver"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// compile runs the whole pipeline on source, failing the test on syntax and semantic errors. Errors of the code
+// generator are returned.
+func compile(t *testing.T, source string) (*codegen.Function, error) {
+	t.Helper()
+	program, err := parser.Parse("test.lox", source)
+	if err != nil {
+		t.Fatalf("syntax errors: %v", err)
+	}
+	program = desugar.Program(program)
+	src := diagnostic.NewSource("test.lox", source)
+	resolution, err := resolver.Resolve(program, src)
+	if err != nil {
+		t.Fatalf("semantic errors: %v", err)
+	}
+	return codegen.Compile(program, src, resolution)
+}
+
+// diagnosticsOf returns the diagnostics of err, failing the test if err is not a [diagnostic.List].
+func diagnosticsOf(t *testing.T, err error) diagnostic.List {
+	t.Helper()
+	var diagnostics diagnostic.List
+	if !errors.As(err, &diagnostics) {
+		t.Fatalf("got error %v, want diagnostics", err)
+	}
+	return diagnostics
+}
+
+func TestConstantPoolOverflow(t *testing.T) {
+	builder := new(strings.Builder)
+	for i := range 70000 {
+		fmt.Fprintf(builder, "print %d;\n", i)
+	}
+	source := builder.String()
+
+	_, err := compile(t, source)
+	diagnostics := diagnosticsOf(t, err)
+	if len(diagnostics) != 1 {
+		t.Fatalf("got %d diagnostics, want 1", len(diagnostics))
+	}
+	d := diagnostics[0]
+	if d.Code() != "limit-exceeded" {
+		t.Errorf("got code %q, want limit-exceeded", d.Code())
+	}
+	// The pool is full with the constants 0 to 65534, so 65535 is the first one rejected.
+	start := strings.Index(source, "print 65535;") + len("print ")
+	want := diagnostic.Span{Start: start, End: start + len("65535")}
+	if span, _ := d.Span(); span != want {
+		t.Errorf("got span %v, want %v", span, want)
+	}
+}
+
+func TestConstantPoolOverflowPerChunk(t *testing.T) {
+	// Every function has its own constant pool, so filling the pool of one function does not affect the others.
+	builder := new(strings.Builder)
+	builder.WriteString("fun f() {\n")
+	for i := range 65540 {
+		fmt.Fprintf(builder, "print %d;\n", i)
+	}
+	builder.WriteString("}\nfun g() {\n")
+	for i := range 65540 {
+		fmt.Fprintf(builder, "print %d;\n", i)
+	}
+	builder.WriteString("}\nprint 1;\n")
+
+	_, err := compile(t, builder.String())
+	if diagnostics := diagnosticsOf(t, err); len(diagnostics) != 2 {
+		t.Errorf("got %d diagnostics, want one for each function", len(diagnostics))
+	}
+}
+
+// repeat returns a block of n print statements, taking 4 bytes of code each.
+func repeat(n int) string {
+	return "{\n" + strings.Repeat("print 1;\n", n) + "}"
+}
+
+func TestJumpOverflow(t *testing.T) {
+	body := repeat(9000)
+	tests := []struct {
+		name   string
+		source string
+		// blamed are the spans of the expected diagnostics as the source text they cover.
+		blamed []string
+	}{
+		{
+			name:   "if",
+			source: "if (true) " + body,
+			blamed: []string{body},
+		},
+		{
+			name:   "else",
+			source: "if (true) print 1; else " + body,
+			blamed: []string{body},
+		},
+		{
+			// The loop jumps back over the whole loop, and the exit jump skips the body forward.
+			name:   "while",
+			source: "while (true) " + body,
+			blamed: []string{"while (true) " + body, body},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := compile(t, test.source)
+			diagnostics := diagnosticsOf(t, err)
+			if len(diagnostics) != len(test.blamed) {
+				t.Fatalf("got %d diagnostics, want %d: %v", len(diagnostics), len(test.blamed), err)
+			}
+			for i, d := range diagnostics {
+				if d.Code() != "limit-exceeded" {
+					t.Errorf("got code %q, want limit-exceeded", d.Code())
+				}
+				start := strings.LastIndex(test.source, test.blamed[i])
+				want := diagnostic.Span{Start: start, End: start + len(test.blamed[i])}
+				if span, _ := d.Span(); span != want {
+					t.Errorf("diagnostic %q: got span %v, want %v", d.Message(), span, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLongJumps(t *testing.T) {
+	// 8000 print statements take 32000 bytes, which is close to the limit of 32767 bytes.
+	script, err := compile(t, "var i = 0;\nwhile (i < 1) { i = i + 1; "+repeat(8000)+" }\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder := new(strings.Builder)
+	if err := script.Disassemble(builder); err != nil {
+		t.Fatal(err)
+	}
+	// Every jump must land on an instruction of the chunk.
+	offsets := make(map[string]bool)
+	var targets []string
+	for _, line := range strings.Split(builder.String(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		offsets[fields[0]] = true
+		if fields[len(fields)-2] == "->" {
+			targets = append(targets, fields[len(fields)-1])
+		}
+	}
+	if len(targets) != 2 {
+		t.Fatalf("got %d jumps, want 2", len(targets))
+	}
+	for _, target := range targets {
+		if !offsets[target] {
+			t.Errorf("jump to %s, which is not an instruction", target)
+		}
+	}
+}
+
+func TestVariableLimits(t *testing.T) {
+	// declare writes the declarations of count variables named prefix0, prefix1...
+	declare := func(builder *strings.Builder, prefix string, count int) {
+		for i := range count {
+			fmt.Fprintf(builder, "var %s%d = %d;\n", prefix, i, i)
+		}
+	}
+
+	// The first slot of a function holds the callee, so there is room for 255 locals.
+	locals := new(strings.Builder)
+	locals.WriteString("fun f() {\n")
+	declare(locals, "v", 256)
+	locals.WriteString("}\n")
+
+	// g captures 128 locals of f and 128 upvalues of f, one more than it can.
+	upvalues := new(strings.Builder)
+	upvalues.WriteString("fun outer() {\n")
+	declare(upvalues, "u", 128)
+	upvalues.WriteString("fun f() {\n")
+	declare(upvalues, "v", 128)
+	upvalues.WriteString("fun g() {\n")
+	for i := range 128 {
+		fmt.Fprintf(upvalues, "print u%d + v%d;\n", i, i)
+	}
+	upvalues.WriteString("}\n}\n}\n")
+
+	tests := []struct {
+		name    string
+		source  string
+		message string
+		// blamed is the text covered by the diagnostic, at its last occurrence in source.
+		blamed string
+	}{
+		{
+			name:    "locals",
+			source:  locals.String(),
+			message: "too many local variables, cannot define v255",
+			blamed:  "v255",
+		},
+		{
+			name:    "upvalues",
+			source:  upvalues.String(),
+			message: "too many closure variables in function, the limit is 255",
+			blamed:  "v127",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := compile(t, test.source)
+			diagnostics := diagnosticsOf(t, err)
+			if len(diagnostics) != 1 {
+				t.Fatalf("got %d diagnostics, want 1: %v", len(diagnostics), err)
+			}
+			d := diagnostics[0]
+			if d.Code() != "limit-exceeded" || d.Message() != test.message {
+				t.Errorf("got [%s] %q, want [limit-exceeded] %q", d.Code(), d.Message(), test.message)
+			}
+			start := strings.LastIndex(test.source, test.blamed)
+			want := diagnostic.Span{Start: start, End: start + len(test.blamed)}
+			if span, _ := d.Span(); span != want {
+				t.Errorf("got span %v, want %v", span, want)
+			}
+		})
+	}
+}
+
+// TestDisassemblyGolden compares the disassembly of small programs with testdata/<name>.golden. Run the test with
+// -update to regenerate the golden files after changing the code generator on purpose.
+func TestDisassemblyGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{
+			// Operators without a dedicated instruction are derived from their negation.
+			name:   "comparisons",
+			source: "print 1 == 2;\nprint 1 != 2;\nprint 1 > 2;\nprint 1 >= 2;\nprint 1 < 2;\nprint 1 <= 2;\n",
+		},
+		{
+			name:   "arithmetic",
+			source: "print -(1 + 2) * 3 / 4 - 5;\nprint !true;\nprint nil;\n",
+		},
+		{
+			name:   "logical",
+			source: "print true and false;\nprint nil or \"default\";\n",
+		},
+		{
+			// Equal numbers and strings share one constant, and every line gets its own run in the line table.
+			name:   "constants",
+			source: "print 1 + 1;\nprint \"a\" + \"b\" + \"a\";\nprint 1 +\n  2;\n",
+		},
+		{
+			name:   "if",
+			source: "if (true) print 1;\nif (false) print 2; else print 3;\n",
+		},
+		{
+			name:   "while",
+			source: "var i = 0;\nwhile (i < 3) {\n  print i;\n  i = i + 1;\n}\n",
+		},
+		{
+			// The loop variable is a local of the block around the lowered loop, popped at its end.
+			name:   "for",
+			source: "for (var i = 0; i < 3; i = i + 1) print i;\n",
+		},
+		{
+			// inner captures x from a local of middle, and y through an upvalue of middle.
+			name: "closures",
+			source: "fun outer() {\n  var y = 1;\n  fun middle(x) {\n    fun inner() {\n      x = x + y;\n      return x;\n" +
+				"    }\n    return inner;\n  }\n  return middle;\n}\nprint outer()(2)();\n",
+		},
+		{
+			// Functions return nil implicitly, or this if they are initializers.
+			name:   "returns",
+			source: "fun f() {}\nfun g() { return; }\nclass A { init() {} m() { return; } }\n",
+		},
+		{
+			// Direct method calls are compiled into Invoke and SuperInvoke, other accesses into properties.
+			name: "classes",
+			source: "class A {\n  init(x) { this.x = x; }\n  get() { return this.x; }\n}\n" +
+				"class B < A {\n  get() { return super.get() + 1; }\n  bound() { return super.get; }\n}\n" +
+				"var b = B(1);\nprint b.get();\nvar f = b.bound;\nprint f()();\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			script, err := compile(t, test.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			builder := new(strings.Builder)
+			if err := script.Disassemble(builder); err != nil {
+				t.Fatal(err)
+			}
+			got := builder.String()
+
+			golden := filepath.Join("testdata", test.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("disassembled:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	// u16, u32 and str append little endian integers and length-prefixed strings, like the encoding does.
+	u16 := func(b []byte, v int) []byte { return binary.LittleEndian.AppendUint16(b, uint16(v)) }
+	u32 := func(b []byte, v int) []byte { return binary.LittleEndian.AppendUint32(b, uint32(v)) }
+	str := func(b []byte, s string) []byte { return append(u32(b, len(s)), s...) }
+
+	tests := []struct {
+		name   string
+		source string
+		want   func() []byte
+	}{
+		{
+			name:   "script",
+			source: "print 1;",
+			want: func() []byte {
+				b := str(nil, "")
+				b = append(b, 0, 0) // Arity and upvalue count.
+				b = u16(b, 1)
+				b = append(b, 0) // Number tag.
+				b = binary.LittleEndian.AppendUint64(b, math.Float64bits(1))
+				b = u32(b, 6)
+				b = append(b, byte(codegen.Constant), 0, 0, byte(codegen.Print), byte(codegen.Nil), byte(codegen.Return))
+				b = u32(b, 1)
+				b = u32(b, 1) // Line 1 covers all 6 bytes.
+				return u32(b, 6)
+			},
+		},
+		{
+			name:   "nested function",
+			source: "fun f(a) {\n  print a;\n}",
+			want: func() []byte {
+				b := str(nil, "")
+				b = append(b, 0, 0)
+				b = u16(b, 1)
+				b = append(b, 2) // Function tag.
+				{
+					b = str(b, "f")
+					b = append(b, 1, 0)
+					b = u16(b, 0)
+					b = u32(b, 5)
+					b = append(b, byte(codegen.GetLocal), 1, byte(codegen.Print), byte(codegen.Nil), byte(codegen.Return))
+					b = u32(b, 2)
+					b = u32(b, 2) // print a covers 3 bytes.
+					b = u32(b, 3)
+					b = u32(b, 3) // The implicit return is on the line of the closing brace.
+					b = u32(b, 2)
+				}
+				b = u32(b, 8)
+				b = append(b, byte(codegen.Fun), 0, 0, byte(codegen.SetGlobal), 0, byte(codegen.Pop))
+				b = append(b, byte(codegen.Nil), byte(codegen.Return))
+				b = u32(b, 1)
+				b = u32(b, 1) // Every chunk has its own line table, where the script is all on line 1.
+				return u32(b, 8)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			script, err := compile(t, test.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buffer := new(bytes.Buffer)
+			n, err := script.WriteTo(buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := test.want()
+			if got := buffer.Bytes(); !bytes.Equal(got, want) {
+				t.Errorf("encoded\n% x\nwant\n% x", got, want)
+			}
+			if n != int64(buffer.Len()) {
+				t.Errorf("WriteTo returned %d, wrote %d bytes", n, buffer.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package codegen
+
+import (
+	"math"
+
+	"github.com/mussel-lox/clam/ast"
+)
+
+func (c *Compiler) VisitStatementDeclaration(s *ast.StatementDeclaration) {
+	s.Statement.Accept(c)
+}
+
+func (c *Compiler) VisitClass(decl *ast.ClassDeclaration) {
+	c.mark(decl)
+	name, ok := c.identifierConstant(decl.Name)
+	if !ok {
+		return
+	}
+	c.chunk.EmitConstant(Class, name)
+	c.defineVariable(decl.Name)
+
+	if decl.Baseclass != nil {
+		// The baseclass lives in a local named super, so that methods capture it as an upvalue.
+		c.VisitIdentifier(decl.Baseclass)
+		c.beginScope()
+		c.declareLocal(ast.Identifier{Span: decl.Baseclass.Span, Name: "super"})
+		c.emitVariable(GetLocal, GetUpvalue, GetGlobal, decl.Name)
+		c.chunk.Emit(Inherit)
+	}
+
+	c.emitVariable(GetLocal, GetUpvalue, GetGlobal, decl.Name)
+	for _, method := range decl.Methods {
+		kind := methodFrame
+		if method.Name.Name == "init" {
+			kind = initializerFrame
+		}
+		c.emitFunction(method, kind)
+		if name, ok := c.identifierConstant(method.Name); ok {
+			c.chunk.EmitConstant(Method, name)
+		}
+	}
+	c.chunk.Emit(Pop)
+
+	if decl.Baseclass != nil {
+		c.endScope()
+	}
+}
+
+func (c *Compiler) VisitFun(f *ast.FunDeclaration) {
+	c.mark(f)
+	// The function is declared before its body is compiled, so that it can refer to itself recursively.
+	if c.scopeDepth > 0 {
+		c.declareLocal(f.Name)
+		c.emitFunction(f, functionFrame)
+		return
+	}
+	c.emitFunction(f, functionFrame)
+	c.defineVariable(f.Name)
+}
+
+func (c *Compiler) VisitVar(v *ast.VarDeclaration) {
+	c.mark(v)
+	if v.Initializer != nil {
+		v.Initializer.Accept(c)
+		c.mark(v)
+	} else {
+		c.chunk.Emit(Nil)
+	}
+	c.defineVariable(v.Name)
+}
+
+// defineVariable makes the value on the top of the stack a variable named name, which is a local in block scopes or a
+// global otherwise.
+func (c *Compiler) defineVariable(name ast.Identifier) {
+	if c.scopeDepth > 0 {
+		c.declareLocal(name)
+		return
+	}
+	c.emitGlobal(SetGlobal, name)
+	c.chunk.Emit(Pop)
+}
+
+// emitFunction compiles f into a nested [Function] and emits the instructions creating it at runtime.
+func (c *Compiler) emitFunction(f *ast.FunDeclaration, kind frameKind) {
+	if len(f.Parameters) > math.MaxUint8 {
+		c.report(f.Parameters[math.MaxUint8], codeLimitExceeded, "cannot have more than 255 parameters")
+	}
+
+	c.beginFunction(NewFunction(f.Name.Name, len(f.Parameters)), kind)
+	c.beginScope()
+	for _, parameter := range f.Parameters {
+		c.declareLocal(parameter)
+	}
+	for _, decl := range f.Body.Declarations {
+		decl.Accept(c)
+	}
+	// The scope is not ended explicitly since returning discards the whole stack frame.
+	c.chunk.MarkLine(c.lineOf(f.Body.End - 1))
+	function := c.endFunction()
+
+	c.mark(f)
+	index, ok := c.addConstant(f, function)
+	if !ok {
+		return
+	}
+	if len(function.Upvalues) == 0 {
+		c.chunk.EmitConstant(Fun, index)
+		return
+	}
+	c.chunk.EmitConstant(Closure, index)
+	for _, upvalue := range function.Upvalues {
+		c.chunk.EmitCapture(upvalue)
+	}
+}
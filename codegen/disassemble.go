@@ -0,0 +1,93 @@
+package codegen
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Disassemble writes a human-readable listing of the function and all functions nested in it into w.
+func (f *Function) Disassemble(w io.Writer) error {
+	if err := f.Chunk.Disassemble(w, f.String()); err != nil {
+		return err
+	}
+	for _, constant := range f.Chunk.constants {
+		if function, isFunction := constant.(*Function); isFunction {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+			if err := function.Disassemble(w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Disassemble writes a human-readable listing of the chunk into w, one instruction per line.
+func (c *Chunk) Disassemble(w io.Writer, name string) error {
+	builder := new(strings.Builder)
+	_, _ = fmt.Fprintf(builder, "== %s ==\n", name)
+	for offset := 0; offset < len(c.code); {
+		offset = c.disassembleInstruction(builder, offset)
+	}
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func (c *Chunk) disassembleInstruction(builder *strings.Builder, offset int) int {
+	_, _ = fmt.Fprintf(builder, "%04d ", offset)
+	if line := c.LineOf(offset); offset > 0 && line == c.LineOf(offset-1) {
+		builder.WriteString("   | ")
+	} else {
+		_, _ = fmt.Fprintf(builder, "%4d ", line)
+	}
+
+	op := OperationCode(c.code[offset])
+	kind := operandKinds[op]
+	end := offset + 1 + operandWidths[kind]
+	if end > len(c.code) {
+		_, _ = fmt.Fprintf(builder, "%-12s <truncated>\n", op)
+		return len(c.code)
+	}
+	operand := c.code[offset+1 : end]
+
+	switch kind {
+	case noOperand:
+		_, _ = fmt.Fprintf(builder, "%s\n", op)
+	case constantOperand:
+		index := binary.LittleEndian.Uint16(operand)
+		_, _ = fmt.Fprintf(builder, "%-12s %4d (%s)\n", op, index, c.describeConstant(ConstantIndex(index)))
+	case globalOperand, localOperand, upvalueOperand:
+		_, _ = fmt.Fprintf(builder, "%-12s %4d\n", op, operand[0])
+	case jumpOperand:
+		distance := JumpOffset(binary.LittleEndian.Uint16(operand))
+		_, _ = fmt.Fprintf(builder, "%-12s %4d -> %04d\n", op, distance, end+int(distance))
+	case captureOperand:
+		kind := "upvalue"
+		if operand[0] != 0 {
+			kind = "local"
+		}
+		_, _ = fmt.Fprintf(builder, "%-12s %4d (%s)\n", op, operand[1], kind)
+	case invokeOperand:
+		index := binary.LittleEndian.Uint16(operand)
+		arguments := binary.LittleEndian.Uint16(operand[2:])
+		_, _ = fmt.Fprintf(builder, "%-12s %4d (%s) %d\n", op, index, c.describeConstant(ConstantIndex(index)), arguments)
+	case callOperand:
+		_, _ = fmt.Fprintf(builder, "%-12s %4d\n", op, binary.LittleEndian.Uint16(operand))
+	}
+	return end
+}
+
+func (c *Chunk) describeConstant(index ConstantIndex) string {
+	if int(index) >= len(c.constants) {
+		return "<invalid>"
+	}
+	switch value := c.constants[index].(type) {
+	case string:
+		return fmt.Sprintf("%q", value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,96 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Tags of constants in the encoded constant pool.
+const (
+	numberTag byte = iota
+	stringTag
+	functionTag
+)
+
+// WriteTo encodes the function into w, implementing [io.WriterTo]. All integers are little endian, and a function is
+// laid out as
+//
+//	u32 name length and UTF-8 bytes of the name
+//	u8 arity
+//	u8 upvalue count, then each upvalue as u8 is-local flag and u8 index
+//	the encoded chunk
+//
+// where a chunk is laid out as
+//
+//	u16 constant count, then each constant as a tag byte followed by
+//	    f64 for numbers, u32 length and UTF-8 bytes for strings, or an encoded function
+//	u32 code length, then the code
+//	u32 line run count, then each run as u32 line and u32 byte count
+func (f *Function) WriteTo(w io.Writer) (int64, error) {
+	buffer := new(bytes.Buffer)
+	if err := f.encode(buffer); err != nil {
+		return 0, err
+	}
+	return buffer.WriteTo(w)
+}
+
+func (f *Function) encode(buffer *bytes.Buffer) error {
+	if f.Arity > math.MaxUint8 || len(f.Upvalues) > math.MaxUint8 {
+		return fmt.Errorf("cannot encode function %s with %d parameters and %d upvalues, the limit is %d",
+			f.Name, f.Arity, len(f.Upvalues), math.MaxUint8)
+	}
+	encodeString(buffer, f.Name)
+	buffer.WriteByte(byte(f.Arity))
+	buffer.WriteByte(byte(len(f.Upvalues)))
+	for _, upvalue := range f.Upvalues {
+		if upvalue.IsLocal {
+			buffer.WriteByte(1)
+		} else {
+			buffer.WriteByte(0)
+		}
+		buffer.WriteByte(byte(upvalue.Index))
+	}
+	return f.Chunk.encode(buffer)
+}
+
+func (c *Chunk) encode(buffer *bytes.Buffer) error {
+	if len(c.constants) > math.MaxUint16 {
+		return fmt.Errorf("cannot encode %d constants, the limit is %d", len(c.constants), math.MaxUint16)
+	}
+	buffer.Write(binary.LittleEndian.AppendUint16(nil, uint16(len(c.constants))))
+	for _, constant := range c.constants {
+		switch value := constant.(type) {
+		case float64:
+			buffer.WriteByte(numberTag)
+			buffer.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(value)))
+		case string:
+			buffer.WriteByte(stringTag)
+			encodeString(buffer, value)
+		case *Function:
+			buffer.WriteByte(functionTag)
+			if err := value.encode(buffer); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("cannot encode constant of type %T", constant)
+		}
+	}
+
+	buffer.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(c.code))))
+	buffer.Write(c.code)
+
+	buffer.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(c.lines))))
+	for _, run := range c.lines {
+		buffer.Write(binary.LittleEndian.AppendUint32(nil, uint32(run.line)))
+		buffer.Write(binary.LittleEndian.AppendUint32(nil, uint32(run.count)))
+	}
+	return nil
+}
+
+func encodeString(buffer *bytes.Buffer, str string) {
+	buffer.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(str))))
+	buffer.WriteString(str)
+}
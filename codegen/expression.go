@@ -0,0 +1,183 @@
+package codegen
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mussel-lox/clam/ast"
+)
+
+// binaryOperations maps operators to the instructions evaluating them. Operators without a dedicated instruction are
+// derived from their negation, e.g. a >= b is !(a < b).
+var binaryOperations = map[ast.BinaryOperator][]OperationCode{
+	ast.BinopEqual:        {Equal},
+	ast.BinopNotEqual:     {Equal, Not},
+	ast.BinopGreater:      {Greater},
+	ast.BinopGreaterEqual: {Less, Not},
+	ast.BinopLess:         {Less},
+	ast.BinopLessEqual:    {Greater, Not},
+	ast.BinopAdd:          {Add},
+	ast.BinopSubtract:     {Subtract},
+	ast.BinopMultiply:     {Multiply},
+	ast.BinopDivide:       {Divide},
+}
+
+// VisitAssignment compiles an assignment, whose target is checked by the parser to be a variable or a property other
+// than those of super.
+func (c *Compiler) VisitAssignment(a *ast.AssignmentExpression) {
+	switch target := a.Target.(type) {
+	case *ast.Identifier:
+		a.Value.Accept(c)
+		c.mark(a)
+		c.emitReference(SetLocal, SetUpvalue, SetGlobal, target)
+	case *ast.PropertyAccessExpression:
+		if super, isSuper := target.Target.(ast.Super); isSuper {
+			c.report(super, codeInternal, "internal error: assignment to a property of super is not rejected by the parser")
+			return
+		}
+		target.Target.Accept(c)
+		a.Value.Accept(c)
+		c.mark(a)
+		if name, ok := c.identifierConstant(target.Property); ok {
+			c.chunk.EmitConstant(SetProperty, name)
+		}
+	default:
+		c.report(a.Target, codeInternal, fmt.Sprintf("internal error: assignment to %T is not rejected by the parser", a.Target))
+	}
+}
+
+func (c *Compiler) VisitBinary(b *ast.BinaryExpression) {
+	switch b.Operator {
+	case ast.BinopLogicalAnd:
+		// JumpIfFalse leaves the condition on the stack, which is exactly the result if the left operand is falsy.
+		b.Left.Accept(c)
+		end := c.chunk.EmitJump(JumpIfFalse)
+		c.chunk.Emit(Pop)
+		b.Right.Accept(c)
+		c.patchJump(b.Right, end)
+		return
+	case ast.BinopLogicalOr:
+		b.Left.Accept(c)
+		otherwise := c.chunk.EmitJump(JumpIfFalse)
+		end := c.chunk.EmitJump(Jump)
+		c.patchJump(b.Right, otherwise)
+		c.chunk.Emit(Pop)
+		b.Right.Accept(c)
+		c.patchJump(b.Right, end)
+		return
+	}
+
+	operations, exists := binaryOperations[b.Operator]
+	if !exists {
+		panic(fmt.Sprint("uncovered binary operator ", b.Operator))
+	}
+	b.Left.Accept(c)
+	b.Right.Accept(c)
+	c.mark(b)
+	for _, op := range operations {
+		c.chunk.Emit(op)
+	}
+}
+
+func (c *Compiler) VisitUnary(u *ast.UnaryExpression) {
+	u.Operand.Accept(c)
+	c.mark(u)
+	switch u.Operator {
+	case ast.UopNegate:
+		c.chunk.Emit(Negate)
+	case ast.UopLogicalNot:
+		c.chunk.Emit(Not)
+	default:
+		panic(fmt.Sprint("uncovered unary operator ", u.Operator))
+	}
+}
+
+func (c *Compiler) VisitInvocation(i *ast.InvocationExpression) {
+	if len(i.Arguments) > math.MaxUint8 {
+		c.report(i.Arguments[math.MaxUint8], codeLimitExceeded, "cannot have more than 255 arguments")
+		return
+	}
+	arguments := CallPosition(len(i.Arguments))
+
+	// Calling a method directly is compiled into Invoke, which avoids creating a bound method.
+	if method, isMethod := i.Callee.(*ast.PropertyAccessExpression); isMethod {
+		if super, isSuper := method.Target.(ast.Super); isSuper {
+			c.VisitThis(ast.This(super))
+			c.emitArguments(i.Arguments)
+			c.emitVariable(GetLocal, GetUpvalue, GetGlobal, ast.Identifier{Span: super.Span, Name: "super"})
+			c.mark(i)
+			if name, ok := c.identifierConstant(method.Property); ok {
+				c.chunk.EmitInvoke(SuperInvoke, name, arguments)
+			}
+			return
+		}
+		method.Target.Accept(c)
+		c.emitArguments(i.Arguments)
+		c.mark(i)
+		if name, ok := c.identifierConstant(method.Property); ok {
+			c.chunk.EmitInvoke(Invoke, name, arguments)
+		}
+		return
+	}
+
+	i.Callee.Accept(c)
+	c.emitArguments(i.Arguments)
+	c.mark(i)
+	c.chunk.EmitCall(arguments)
+}
+
+func (c *Compiler) emitArguments(arguments []ast.Expression) {
+	for _, argument := range arguments {
+		argument.Accept(c)
+	}
+}
+
+func (c *Compiler) VisitPropertyAccess(p *ast.PropertyAccessExpression) {
+	name, ok := c.identifierConstant(p.Property)
+	if !ok {
+		return
+	}
+	if super, isSuper := p.Target.(ast.Super); isSuper {
+		c.VisitThis(ast.This(super))
+		c.emitVariable(GetLocal, GetUpvalue, GetGlobal, ast.Identifier{Span: super.Span, Name: "super"})
+		c.mark(p)
+		c.chunk.EmitConstant(GetSuper, name)
+		return
+	}
+	p.Target.Accept(c)
+	c.mark(p)
+	c.chunk.EmitConstant(GetProperty, name)
+}
+
+func (c *Compiler) VisitBooleanLiteral(b ast.BooleanLiteral) {
+	c.mark(b)
+	if b.Value {
+		c.chunk.Emit(True)
+	} else {
+		c.chunk.Emit(False)
+	}
+}
+
+func (c *Compiler) VisitThis(t ast.This) {
+	c.mark(t)
+	c.emitVariable(GetLocal, GetUpvalue, GetGlobal, ast.Identifier{Span: t.Span, Name: "this"})
+}
+
+// VisitSuper is never called on programs from the parser, where super is always the target of a property access, which
+// compiles it along with the property.
+func (c *Compiler) VisitSuper(s ast.Super) {
+	c.report(s, codeInternal, "internal error: super without a property access is not rejected by the parser")
+}
+
+func (c *Compiler) VisitNil(n ast.Nil) {
+	c.mark(n)
+	c.chunk.Emit(Nil)
+}
+
+func (c *Compiler) VisitNumberLiteral(n ast.NumberLiteral) { c.emitConstant(n, n.Value) }
+func (c *Compiler) VisitStringLiteral(s ast.StringLiteral) { c.emitConstant(s, s.Value) }
+
+func (c *Compiler) VisitIdentifier(i *ast.Identifier) {
+	c.mark(i)
+	c.emitReference(GetLocal, GetUpvalue, GetGlobal, i)
+}
@@ -0,0 +1,35 @@
+package codegen
+
+// Function is a compiled Lox function. The top-level script is compiled into a Function too, with an empty name.
+//
+// Functions are stored in the constant pool of the enclosing [Chunk] and instantiated by Fun, or by Closure followed by
+// one Capture instruction per [Upvalue].
+type Function struct {
+	Name     string
+	Arity    int
+	Upvalues []Upvalue
+	Chunk    *Chunk
+}
+
+// Upvalue describes where a closure captures a free variable from when it is created: a local slot of the enclosing
+// function if IsLocal, or otherwise an upvalue of the enclosing function.
+type Upvalue struct {
+	IsLocal bool
+	Index   UpvalueIndex
+}
+
+// NewFunction creates a [Function] with an empty [Chunk].
+func NewFunction(name string, arity int) *Function {
+	return &Function{
+		Name:  name,
+		Arity: arity,
+		Chunk: NewChunk(),
+	}
+}
+
+func (f *Function) String() string {
+	if f.Name == "" {
+		return "<script>"
+	}
+	return "<fn " + f.Name + ">"
+}
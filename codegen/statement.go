@@ -0,0 +1,72 @@
+package codegen
+
+import (
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/desugar"
+)
+
+func (c *Compiler) VisitExpressionStatement(es *ast.ExpressionStatement) {
+	c.mark(es)
+	es.Expression.Accept(c)
+	c.chunk.Emit(Pop)
+}
+
+// VisitFor compiles f lowered into a while loop. Programs are usually lowered by package desugar before resolution, so
+// that the scope around the loop is resolved as well.
+func (c *Compiler) VisitFor(f *ast.ForStatement) {
+	desugar.For(f).Accept(c)
+}
+
+func (c *Compiler) VisitIf(i *ast.IfStatement) {
+	c.mark(i)
+	i.Condition.Accept(c)
+	otherwise := c.chunk.EmitJump(JumpIfFalse)
+	c.chunk.Emit(Pop)
+	i.Then.Accept(c)
+	end := c.chunk.EmitJump(Jump)
+	c.patchJump(i.Then, otherwise)
+	c.chunk.Emit(Pop)
+	if i.Otherwise != nil {
+		i.Otherwise.Accept(c)
+		c.patchJump(i.Otherwise, end)
+	} else {
+		c.patchJump(i, end)
+	}
+}
+
+func (c *Compiler) VisitPrint(p *ast.PrintStatement) {
+	c.mark(p)
+	p.Expression.Accept(c)
+	c.mark(p)
+	c.chunk.Emit(Print)
+}
+
+func (c *Compiler) VisitReturn(r *ast.ReturnStatement) {
+	c.mark(r)
+	if r.Expression == nil {
+		c.emitReturn()
+		return
+	}
+	r.Expression.Accept(c)
+	c.chunk.Emit(Return)
+}
+
+func (c *Compiler) VisitWhile(w *ast.WhileStatement) {
+	c.mark(w)
+	start := c.chunk.Len()
+	w.Condition.Accept(c)
+	exit := c.chunk.EmitJump(JumpIfFalse)
+	c.chunk.Emit(Pop)
+	w.Body.Accept(c)
+	c.emitLoop(w, start)
+	c.patchJump(w.Body, exit)
+	c.chunk.Emit(Pop)
+}
+
+func (c *Compiler) VisitBlock(b *ast.BlockStatement) {
+	c.beginScope()
+	for _, decl := range b.Declarations {
+		decl.Accept(c)
+	}
+	c.endScope()
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/codegen"
+	"github.com/mussel-lox/clam/desugar"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+	"github.com/mussel-lox/clam/parser"
+	"github.com/mussel-lox/clam/resolver"
+)
+
+// codeIO is the code of the diagnostics about failures to read the source code or write the output.
+const codeIO = "io-error"
+
+var (
+	// errHelp is returned by parseOptions when the user asks for help of a command.
+	errHelp = flag.ErrHelp
+	// errCompilation is returned by commands after the diagnostics have been reported.
+	errCompilation = errors.New("compilation failed")
+)
+
+var commands = map[string]func(*options) error{
+	"build":  buildCommand,
+	"check":  checkCommand,
+	"ast":    astCommand,
+	"disasm": disasmCommand,
+}
+
+// options are the flags shared by every command.
+type options struct {
+	output string
+	color  string
+	ascii  bool
+	format string
+	input  string
+
+	renderer *diagnostic.Renderer
+
+	// diagnostics are collected from every pass, and written in the chosen format once the command finishes.
+	diagnostics diagnostic.List
+}
+
+func parseOptions(command string, args []string) (*options, error) {
+	opts := new(options)
+	flags := flag.NewFlagSet("clam "+command, flag.ContinueOnError)
+	flags.StringVar(&opts.output, "o", "", "write the output into `path` instead of the standard output")
+	flags.StringVar(&opts.color, "color", "auto", "colorize diagnostics: auto, always or never")
+	flags.BoolVar(&opts.ascii, "ascii", false, "render diagnostics in plain ASCII")
+	flags.StringVar(&opts.format, "format", "text", "diagnostic output format: text, json (one object per line) or sarif")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "usage: clam %s [flags] [file]\n\nflags:\n", command)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	switch flags.NArg() {
+	case 0:
+		opts.input = "-"
+	case 1:
+		opts.input = flags.Arg(0)
+	default:
+		_, _ = fmt.Fprintln(flags.Output(), "clam: too many input files")
+		flags.Usage()
+		return nil, errors.New("too many input files")
+	}
+
+	opts.renderer = diagnostic.NewRenderer()
+	opts.renderer.ASCII = opts.ascii
+	switch opts.color {
+	case "auto":
+	case "always":
+		opts.renderer.Color = diagnostic.ColorAlways
+	case "never":
+		opts.renderer.Color = diagnostic.ColorNever
+	default:
+		_, _ = fmt.Fprintf(flags.Output(), "clam: invalid color mode %q\n", opts.color)
+		return nil, errors.New("invalid color mode")
+	}
+	switch opts.format {
+	case "text", "json", "sarif":
+	default:
+		_, _ = fmt.Fprintf(flags.Output(), "clam: invalid diagnostic format %q\n", opts.format)
+		return nil, errors.New("invalid diagnostic format")
+	}
+	return opts, nil
+}
+
+// readSource reads the whole input file (or the standard input) and returns its name with the content.
+func (o *options) readSource() (string, string, error) {
+	if o.input == "-" {
+		content, err := io.ReadAll(os.Stdin)
+		return diagnostic.StdinName, string(content), err
+	}
+	content, err := os.ReadFile(o.input)
+	return o.input, string(content), err
+}
+
+// openOutput opens the output file specified by -o, or the standard output. The returned function must be called to
+// release the file.
+func (o *options) openOutput() (io.Writer, func() error, error) {
+	if o.output == "" || o.output == "-" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	file, err := os.Create(o.output)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, file.Close, nil
+}
+
+// collect keeps the diagnostics of a failed pass, and returns errCompilation.
+func (o *options) collect(err error) error {
+	var diagnostics diagnostic.List
+	if !errors.As(err, &diagnostics) {
+		diagnostics = diagnostic.List{diagnostic.NewDiagnostic(err.Error())}
+	}
+	o.diagnostics = append(o.diagnostics, diagnostics...)
+	return errCompilation
+}
+
+// writeDiagnostics writes the collected diagnostics in the format specified by -format. A SARIF log is written even
+// without any diagnostic.
+func (o *options) writeDiagnostics(w io.Writer) error {
+	switch o.format {
+	case "json":
+		return o.diagnostics.WriteJSONLines(w)
+	case "sarif":
+		return o.diagnostics.WriteSARIF(w, "clam")
+	default:
+		return o.renderer.RenderList(w, o.diagnostics)
+	}
+}
+
+// parse reads the source code and parses it, collecting diagnostics if any.
+func (o *options) parse() ([]ast.Declaration, *diagnostic.Source, error) {
+	name, content, err := o.readSource()
+	if err != nil {
+		return nil, nil, err
+	}
+	program, err := parser.Parse(name, content)
+	if err != nil {
+		return nil, nil, o.collect(err)
+	}
+	return program, diagnostic.NewSource(name, content), nil
+}
+
+// compile parses the source code, lowers it and generates bytecode, collecting diagnostics if any.
+func (o *options) compile() (*codegen.Function, error) {
+	program, source, err := o.parse()
+	if err != nil {
+		return nil, err
+	}
+	program = desugar.Program(program)
+	resolution, err := resolver.Resolve(program, source)
+	if err != nil {
+		return nil, o.collect(err)
+	}
+	script, err := codegen.Compile(program, source, resolution)
+	if err != nil {
+		return nil, o.collect(err)
+	}
+	return script, nil
+}
+
+func checkCommand(opts *options) error {
+	_, err := opts.compile()
+	return err
+}
+
+func astCommand(opts *options) error {
+	program, _, err := opts.parse()
+	if err != nil {
+		return err
+	}
+	w, closeOutput, err := opts.openOutput()
+	if err != nil {
+		return err
+	}
+	return errors.Join(ast.Dump(w, program), closeOutput())
+}
+
+func buildCommand(opts *options) error {
+	script, err := opts.compile()
+	if err != nil {
+		return err
+	}
+	w, closeOutput, err := opts.openOutput()
+	if err != nil {
+		return err
+	}
+	_, err = script.WriteTo(w)
+	return errors.Join(err, closeOutput())
+}
+
+func disasmCommand(opts *options) error {
+	script, err := opts.compile()
+	if err != nil {
+		return err
+	}
+	w, closeOutput, err := opts.openOutput()
+	if err != nil {
+		return err
+	}
+	return errors.Join(script.Disassemble(w), closeOutput())
+}
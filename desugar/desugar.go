@@ -0,0 +1,96 @@
+// Package desugar lowers the syntactic sugar of Lox into simpler constructs, so that the backend handles fewer kinds of
+// nodes. For now, only for loops are lowered, into while loops:
+//
+//	for (initializer; condition; increment) body
+//
+// becomes
+//
+//	{
+//		initializer;
+//		while (condition) {
+//			body;
+//			increment;
+//		}
+//	}
+//
+// The rewriting is done in place, and the nodes of the loop are reused, so their spans still point at the source code.
+// Since the block around the loop adds a scope, variables must be resolved after desugaring.
+package desugar
+
+import "github.com/mussel-lox/clam/ast"
+
+// Program lowers every declaration of program in place, and returns program for convenience.
+func Program(program []ast.Declaration) []ast.Declaration {
+	for _, decl := range program {
+		declaration(decl)
+	}
+	return program
+}
+
+// For lowers a single for loop into a block containing a while loop. The loop must not have any nested for loops, or
+// they are left as they are; use [Program] to lower a whole syntax tree.
+func For(f *ast.ForStatement) *ast.BlockStatement {
+	block := &ast.BlockStatement{Span: f.Span}
+	switch initializer := f.Initializer.(type) {
+	case *ast.VarDeclaration:
+		block.Declarations = append(block.Declarations, initializer)
+	case *ast.ExpressionStatement:
+		block.Declarations = append(block.Declarations, statementDeclaration(initializer))
+	}
+
+	// A missing condition is always true, and located at the whole loop.
+	condition := f.Condition
+	if condition == nil {
+		condition = ast.BooleanLiteral{Span: f.Span, Value: true}
+	}
+	body := f.Body
+	if f.Increment != nil {
+		increment := &ast.ExpressionStatement{Span: f.Increment.Location(), Expression: f.Increment}
+		body = &ast.BlockStatement{
+			Span:         f.Body.Location(),
+			Declarations: []ast.Declaration{statementDeclaration(f.Body), statementDeclaration(increment)},
+		}
+	}
+
+	loop := &ast.WhileStatement{Span: f.Span, Condition: condition, Body: body}
+	block.Declarations = append(block.Declarations, statementDeclaration(loop))
+	return block
+}
+
+func statementDeclaration(stmt ast.Statement) *ast.StatementDeclaration {
+	return &ast.StatementDeclaration{Span: stmt.Location(), Statement: stmt}
+}
+
+func declaration(decl ast.Declaration) {
+	switch d := decl.(type) {
+	case *ast.StatementDeclaration:
+		d.Statement = statement(d.Statement)
+	case *ast.ClassDeclaration:
+		for _, method := range d.Methods {
+			declaration(method)
+		}
+	case *ast.FunDeclaration:
+		statement(d.Body)
+	}
+}
+
+// statement lowers stmt and the statements nested in it, returning the statement replacing stmt.
+func statement(stmt ast.Statement) ast.Statement {
+	switch s := stmt.(type) {
+	case *ast.ForStatement:
+		s.Body = statement(s.Body)
+		return For(s)
+	case *ast.IfStatement:
+		s.Then = statement(s.Then)
+		if s.Otherwise != nil {
+			s.Otherwise = statement(s.Otherwise)
+		}
+	case *ast.WhileStatement:
+		s.Body = statement(s.Body)
+	case *ast.BlockStatement:
+		for _, decl := range s.Declarations {
+			declaration(decl)
+		}
+	}
+	return stmt
+}
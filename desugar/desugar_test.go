@@ -0,0 +1,117 @@
+package desugar_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/desugar"
+	"github.com/mussel-lox/clam/parser"
+)
+
+// shapeOf describes the statements of node in a compact form, printing other nodes as the source code they cover. It
+// tells whether nodes are lowered, and that the nodes of the loops are reused with their spans.
+func shapeOf(source string, node ast.Node) string {
+	text := func(node ast.Node) string { return source[node.Location().Start:node.Location().End] }
+	switch n := node.(type) {
+	case *ast.StatementDeclaration:
+		return shapeOf(source, n.Statement)
+	case *ast.BlockStatement:
+		var declarations []string
+		for _, decl := range n.Declarations {
+			declarations = append(declarations, shapeOf(source, decl))
+		}
+		return "{ " + strings.Join(declarations, " ") + " }"
+	case *ast.WhileStatement:
+		condition := text(n.Condition)
+		if literal, isLiteral := n.Condition.(ast.BooleanLiteral); isLiteral && condition != "true" {
+			condition = fmt.Sprintf("<%t>", literal.Value)
+		}
+		return "while (" + condition + ") " + shapeOf(source, n.Body)
+	case *ast.IfStatement:
+		return "if (" + text(n.Condition) + ") " + shapeOf(source, n.Then)
+	case *ast.FunDeclaration:
+		return "fun " + n.Name.Name + "() " + shapeOf(source, n.Body)
+	case *ast.ClassDeclaration:
+		var methods []string
+		for _, method := range n.Methods {
+			methods = append(methods, shapeOf(source, method))
+		}
+		return "class " + n.Name.Name + " { " + strings.Join(methods, " ") + " }"
+	case *ast.ExpressionStatement:
+		return text(n.Expression) + ";"
+	default:
+		return text(node)
+	}
+}
+
+func TestProgram(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{
+			source: "for (var i = 0; i < 3; i = i + 1) print i;",
+			want:   "{ var i = 0; while (i < 3) { print i; i = i + 1; } }",
+		},
+		{
+			// An assignment initializer is an expression statement, which used to be dropped.
+			source: "for (i = 0; i < 3; i = i + 1) print i;",
+			want:   "{ i = 0; while (i < 3) { print i; i = i + 1; } }",
+		},
+		{
+			// A missing condition is a true literal located at the whole loop.
+			source: "for (;;) print 1;",
+			want:   "{ while (<true>) print 1; }",
+		},
+		{
+			source: "for (; i < 3;) { print i; }",
+			want:   "{ while (i < 3) { print i; } }",
+		},
+		{
+			// Loops are lowered in every function, method, block and statement.
+			source: "fun f() { if (a) for (;b;) for (;c;) print 1; }\nclass A { m() { { for (;d;) print 2; } } }",
+			want:   "fun f() { if (a) { while (b) { while (c) print 1; } } }\nclass A { fun m() { { { while (d) print 2; } } } }",
+		},
+	}
+
+	for _, test := range tests {
+		program, err := parser.Parse("test.lox", test.source)
+		if err != nil {
+			t.Fatalf("%q: syntax errors: %v", test.source, err)
+		}
+		var got []string
+		for _, decl := range desugar.Program(program) {
+			got = append(got, shapeOf(test.source, decl))
+		}
+		if strings.Join(got, "\n") != test.want {
+			t.Errorf("%q: lowered into\n%s\nwant\n%s", test.source, strings.Join(got, "\n"), test.want)
+		}
+	}
+}
+
+func TestForReusesNodes(t *testing.T) {
+	source := "for (i = 0; i < 3; i = i + 1) print i;"
+	program, err := parser.Parse("test.lox", source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop := program[0].(*ast.StatementDeclaration).Statement.(*ast.ForStatement)
+
+	block := desugar.For(loop)
+	if block.Span != loop.Span {
+		t.Errorf("got block span %v, want the span of the loop %v", block.Span, loop.Span)
+	}
+	if len(block.Declarations) != 2 {
+		t.Fatalf("got %d declarations in the block, want the initializer and the loop", len(block.Declarations))
+	}
+	initializer := block.Declarations[0].(*ast.StatementDeclaration).Statement
+	if initializer != loop.Initializer.(*ast.ExpressionStatement) {
+		t.Errorf("got initializer %#v, want the one of the loop %#v", initializer, loop.Initializer)
+	}
+	while := block.Declarations[1].(*ast.StatementDeclaration).Statement.(*ast.WhileStatement)
+	if while.Span != loop.Span || while.Condition != loop.Condition {
+		t.Errorf("got while loop %#v, want the span and the condition of the for loop", while)
+	}
+}
@@ -1,30 +1,70 @@
 // Package diagnostic defines some data structures to display syntax errors more friendly.
 package diagnostic
 
-import (
-	"fmt"
-	"strings"
+import "fmt"
 
-	"github.com/fatih/color"
-)
+// Severity tells how serious a [Diagnostic] is. Notes and help messages are usually attached to another diagnostic by
+// [Diagnostic.Note] and [Diagnostic.Help], rather than reported on their own.
+type Severity int
 
-const contextLines = 2
+const (
+	// Error is the severity of diagnostics which stop the compilation.
+	Error Severity = iota
+	// Warning is the severity of diagnostics about suspicious but valid code.
+	Warning
+	// Note is the severity of additional information.
+	Note
+	// Help is the severity of suggestions to fix the problem.
+	Help
+)
 
-var (
-	printErrorTag       = color.New(color.FgRed).FprintFunc()
-	printErrorMessage   = color.New(color.FgHiWhite).FprintlnFunc()
-	printErrorUnderline = color.New(color.FgHiRed).FprintFunc()
-	printSource         = color.New(color.FgHiBlack).FprintfFunc()
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Note:
+		return "note"
+	case Help:
+		return "help"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
 
-	filenameIndent   = strings.Repeat(" ", 2)
-	sourceLineIndent = strings.Repeat(" ", 4)
-)
+// Span is a range of byte offsets in the source code, the end being exclusive. It has the same layout as ast.Span, so
+// the spans of syntax tree nodes can be converted directly.
+type Span struct {
+	Start int
+	End   int
+}
 
 // Diagnostic contains all information about a syntax error, and can display the error more friendly.
+//
+// Besides the primary position, a diagnostic can point at other code segments with labels, like where a variable was
+// previously declared, and end with notes and help messages.
 type Diagnostic struct {
-	message  string
-	source   *Source
-	position *Position
+	message     string
+	severity    Severity
+	code        string
+	source      *Source
+	position    *Position
+	span        *Span
+	labels      []Label
+	attachments []Attachment
+}
+
+// Label is a secondary code segment of a [Diagnostic] with a short message.
+type Label struct {
+	Span    Span
+	Message string
+}
+
+// Attachment is a note or a help message at the end of a [Diagnostic].
+type Attachment struct {
+	Severity Severity
+	Message  string
 }
 
 // NewDiagnostic creates a [Diagnostic] with only the message.
@@ -32,51 +72,95 @@ func NewDiagnostic(message string) *Diagnostic {
 	return &Diagnostic{message: message}
 }
 
+// WithSeverity changes the [Severity] of [d], which is [Error] by default.
+func (d *Diagnostic) WithSeverity(severity Severity) *Diagnostic {
+	d.severity = severity
+	return d
+}
+
+// WithCode sets a short identifier of the kind of [d], which tools can use to look up or filter diagnostics.
+func (d *Diagnostic) WithCode(code string) *Diagnostic {
+	d.code = code
+	return d
+}
+
+// Label points at another code segment related to [d], explained by message.
+func (d *Diagnostic) Label(span Span, message string) *Diagnostic {
+	d.labels = append(d.labels, Label{Span: span, Message: message})
+	return d
+}
+
+// Note attaches a note to the end of [d].
+func (d *Diagnostic) Note(message string) *Diagnostic {
+	d.attachments = append(d.attachments, Attachment{Severity: Note, Message: message})
+	return d
+}
+
+// Help attaches a help message to the end of [d].
+func (d *Diagnostic) Help(message string) *Diagnostic {
+	d.attachments = append(d.attachments, Attachment{Severity: Help, Message: message})
+	return d
+}
+
 // At specifies the [d.position] of [d].
 func (d *Diagnostic) At(line, column int) *Diagnostic {
 	d.position = NewPosition(line, column)
 	return d
 }
 
+// AtOffset specifies the position of [d] by a byte offset in the source code, which is converted into a [Position]
+// once [d.source] is attached.
+func (d *Diagnostic) AtOffset(offset int) *Diagnostic {
+	return d.AtSpan(Span{Start: offset, End: offset})
+}
+
+// AtSpan specifies the range of source code [d] is about. Its start is the position of [d].
+func (d *Diagnostic) AtSpan(span Span) *Diagnostic {
+	d.span = &span
+	return d
+}
+
 // Attach sets the [d.source] field.
 func (d *Diagnostic) Attach(source *Source) *Diagnostic {
 	d.source = source
 	return d
 }
 
-// Error implements the [error] interface, making [Diagnostic] of the [error] type and can be treated as a regular
-// [error].
-func (d *Diagnostic) Error() string {
-	builder := new(strings.Builder)
+// Message returns the message of [d] without any decoration.
+func (d *Diagnostic) Message() string { return d.message }
 
-	printErrorTag(builder, "error: ")
-	printErrorMessage(builder, d.message)
-	if d.source == nil || d.position == nil {
-		return builder.String()
-	}
+// Severity returns the [Severity] of [d].
+func (d *Diagnostic) Severity() Severity { return d.severity }
+
+// Code returns the identifier set by [Diagnostic.WithCode], or an empty string if there is none.
+func (d *Diagnostic) Code() string { return d.code }
 
-	_, _ = fmt.Fprintf(builder, "%sin %s (%s)\n", filenameIndent, d.source.name, d.position.String())
-	startLine := max(0, d.position.Line-contextLines)
-	lineNumberFormat := fmt.Sprintf("%%%dv ", digitsOf(d.position.Line+1))
-	for i := startLine; i <= d.position.Line; i++ {
-		_, _ = fmt.Fprint(builder, sourceLineIndent)
-		printSource(builder, lineNumberFormat, i+1)
-		printSource(builder, "%s\n", d.source.lines[i])
+// File returns the name of the attached [Source], or an empty string if there is none.
+func (d *Diagnostic) File() string {
+	if d.source == nil {
+		return ""
 	}
-	_, _ = fmt.Fprint(builder, sourceLineIndent)
-	_, _ = fmt.Fprintf(builder, lineNumberFormat, "")
-	for range d.position.Column {
-		_, _ = fmt.Fprint(builder, " ")
+	return d.source.name
+}
+
+// Span returns the range of source code [d] is about, and false if [d] is not located by offsets.
+func (d *Diagnostic) Span() (Span, bool) {
+	if d.span == nil {
+		return Span{}, false
 	}
-	printErrorUnderline(builder, "^ around here\n")
-	return builder.String()
+	return *d.span, true
 }
 
-func digitsOf(x int) int {
-	digits := 0
-	for x > 0 {
-		digits++
-		x /= 10
+// Labels returns the secondary code segments of [d].
+func (d *Diagnostic) Labels() []Label { return d.labels }
+
+// Attachments returns the notes and help messages of [d].
+func (d *Diagnostic) Attachments() []Attachment { return d.attachments }
+
+// Position returns the [Position] of [d], or nil if it is unknown.
+func (d *Diagnostic) Position() *Position {
+	if d.position == nil && d.source != nil && d.span != nil {
+		return d.source.PositionOf(d.span.Start)
 	}
-	return digits
+	return d.position
 }
@@ -0,0 +1,99 @@
+package diagnostic
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonDiagnostic is a [Diagnostic] in JSON. Lines and columns are 1-based, and columns count runes like [Position].
+type jsonDiagnostic struct {
+	File     string           `json:"file,omitempty"`
+	Range    *jsonRange       `json:"range,omitempty"`
+	Severity string           `json:"severity"`
+	Code     string           `json:"code,omitempty"`
+	Message  string           `json:"message"`
+	Labels   []jsonLabel      `json:"labels,omitempty"`
+	Notes    []jsonAttachment `json:"notes,omitempty"`
+}
+
+type jsonRange struct {
+	Start jsonLocation `json:"start"`
+	End   jsonLocation `json:"end"`
+}
+
+type jsonLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+type jsonLabel struct {
+	Range   jsonRange `json:"range"`
+	Message string    `json:"message"`
+}
+
+type jsonAttachment struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// WriteJSONLines writes every [Diagnostic] in [l] as a JSON object on its own line:
+//
+//	{"file":"main.lox","range":{"start":{"line":1,"column":5,"offset":4},"end":{...}},"severity":"error",...}
+func (l List) WriteJSONLines(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, d := range l {
+		if err := encoder.Encode(d.toJSON()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Diagnostic) toJSON() jsonDiagnostic {
+	diag := jsonDiagnostic{
+		File:     d.File(),
+		Severity: d.severity.String(),
+		Code:     d.code,
+		Message:  d.message,
+	}
+	if r, ok := d.rangeOf(d.span); ok {
+		diag.Range = &r
+	}
+	for _, label := range d.labels {
+		if r, ok := d.rangeOf(&label.Span); ok {
+			diag.Labels = append(diag.Labels, jsonLabel{Range: r, Message: label.Message})
+		}
+	}
+	for _, attachment := range d.attachments {
+		diag.Notes = append(diag.Notes, jsonAttachment{
+			Severity: attachment.Severity.String(),
+			Message:  attachment.Message,
+		})
+	}
+	return diag
+}
+
+// rangeOf converts span into a jsonRange. A diagnostic located by [Diagnostic.At] has an empty range at its position.
+func (d *Diagnostic) rangeOf(span *Span) (jsonRange, bool) {
+	switch {
+	case d.source == nil:
+		return jsonRange{}, false
+	case span != nil:
+		start, end := d.source.PositionOf(span.Start), d.source.PositionOf(max(span.Start, span.End))
+		return jsonRange{
+			Start: jsonLocation{Line: start.Line + 1, Column: start.Column + 1, Offset: d.source.OffsetOf(start)},
+			End:   jsonLocation{Line: end.Line + 1, Column: end.Column + 1, Offset: d.source.OffsetOf(end)},
+		}, true
+	case d.position != nil:
+		location := jsonLocation{
+			Line:   d.position.Line + 1,
+			Column: d.position.Column + 1,
+			Offset: d.source.OffsetOf(d.position),
+		}
+		return jsonRange{Start: location, End: location}, true
+	default:
+		return jsonRange{}, false
+	}
+}
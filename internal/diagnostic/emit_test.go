@@ -0,0 +1,127 @@
+package diagnostic
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteJSONLines(t *testing.T) {
+	source := NewSource("dir/main.lox", "var 名前 = 1;\nvar 名前 = 2;\n")
+	list := List{
+		NewDiagnostic("variable 名前 is already declared in this scope").
+			WithCode("redeclaration").
+			AtSpan(Span{Start: 20, End: 26}).
+			Label(Span{Start: 4, End: 10}, "previously declared here").
+			Note("the first declaration is still in scope").
+			Help("assign to 名前 without var").
+			Attach(source),
+		NewDiagnostic("unused variable").WithSeverity(Warning).At(1, 4).Attach(source),
+		NewDiagnostic("cannot read <stdin>"),
+	}
+	builder := new(strings.Builder)
+	if err := list.WriteJSONLines(builder); err != nil {
+		t.Fatal(err)
+	}
+
+	// Columns count runes, while offsets count bytes. HTML characters are not escaped.
+	want := []string{
+		`{"file":"dir/main.lox",` +
+			`"range":{"start":{"line":2,"column":5,"offset":20},"end":{"line":2,"column":7,"offset":26}},` +
+			`"severity":"error","code":"redeclaration","message":"variable 名前 is already declared in this scope",` +
+			`"labels":[{"range":{"start":{"line":1,"column":5,"offset":4},"end":{"line":1,"column":7,"offset":10}},` +
+			`"message":"previously declared here"}],` +
+			`"notes":[{"severity":"note","message":"the first declaration is still in scope"},` +
+			`{"severity":"help","message":"assign to 名前 without var"}]}`,
+		`{"file":"dir/main.lox",` +
+			`"range":{"start":{"line":2,"column":5,"offset":20},"end":{"line":2,"column":5,"offset":20}},` +
+			`"severity":"warning","message":"unused variable"}`,
+		`{"severity":"error","message":"cannot read <stdin>"}`,
+	}
+	got := strings.Split(strings.TrimSuffix(builder.String(), "\n"), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(got), len(want), builder)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d:\n got %s\nwant %s", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	source := NewSource("dir/my file.lox", "var a = 1;\r\nvar a = 2;\r\n")
+	list := List{
+		NewDiagnostic("variable a is already declared in this scope").
+			WithCode("redeclaration").
+			AtSpan(Span{Start: 16, End: 17}).
+			Label(Span{Start: 4, End: 5}, "previously declared here").
+			Help("assign to a without var, or give the new variable another name").
+			Attach(source),
+		NewDiagnostic("unreachable code").WithSeverity(Warning).AtOffset(12).Attach(source),
+		NewDiagnostic("cannot read <stdin>").WithCode("io-error"),
+	}
+
+	tests := []struct {
+		name string
+		list List
+	}{
+		{"sarif", list},
+		// An empty log still has a run, whose results are an empty array.
+		{"sarif_empty", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := new(strings.Builder)
+			if err := test.list.WriteSARIF(builder, "clam"); err != nil {
+				t.Fatal(err)
+			}
+			got := builder.String()
+
+			golden := filepath.Join("testdata", test.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("written:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestSARIFURIOf(t *testing.T) {
+	tests := []struct {
+		file string
+		uri  string
+	}{
+		{"main.lox", "main.lox"},
+		{"dir/main.lox", "dir/main.lox"},
+		{"dir/my file.lox", "dir/my%20file.lox"},
+		{"100%#1.lox", "100%25%231.lox"},
+		{"ソース.lox", "%E3%82%BD%E3%83%BC%E3%82%B9.lox"},
+		// A colon in the first segment would be taken for a scheme.
+		{"a:b.lox", "./a:b.lox"},
+		{StdinName, "file:///dev/stdin"},
+	}
+	if filepath.Separator == '/' {
+		tests = append(tests, []struct {
+			file string
+			uri  string
+		}{
+			{"/home/me/main.lox", "file:///home/me/main.lox"},
+			{"/home/me/my file.lox", "file:///home/me/my%20file.lox"},
+		}...)
+	}
+	for _, test := range tests {
+		if uri := sarifURIOf(test.file); uri != test.uri {
+			t.Errorf("sarifURIOf(%q) = %q, want %q", test.file, uri, test.uri)
+		}
+	}
+}
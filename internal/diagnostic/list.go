@@ -0,0 +1,37 @@
+package diagnostic
+
+import "strings"
+
+// List is a list of [Diagnostic], which is returned as a single error by the passes reporting as many diagnostics as
+// possible at once.
+type List []*Diagnostic
+
+// Add appends a [Diagnostic] to [l].
+func (l *List) Add(d *Diagnostic) { *l = append(*l, d) }
+
+// Err returns [l] as an error, or nil if [l] is empty.
+func (l List) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Error implements the [error] interface, rendering every [Diagnostic] in order.
+func (l List) Error() string {
+	builder := new(strings.Builder)
+	for _, d := range l {
+		builder.WriteString(d.Error())
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// Unwrap returns every [Diagnostic] in [l], so that [errors.As] can find them.
+func (l List) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, d := range l {
+		errs[i] = d
+	}
+	return errs
+}
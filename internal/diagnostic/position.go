@@ -4,6 +4,10 @@ import "fmt"
 
 // Position is the type used to highlight the code segments in a diagnostic. After all, we need to print the source code
 // line by line.
+//
+// Both fields are 0-based. Column counts Unicode code points (runes) from the start of the line, ignoring carriage
+// returns. Editors counting in UTF-16 code units or bytes can convert it with [Source.UTF16Column] and
+// [Source.ByteColumn].
 type Position struct {
 	Line   int
 	Column int
@@ -0,0 +1,375 @@
+package diagnostic
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	// ColorAuto colorizes the output if it is a terminal, and the NO_COLOR environment variable is empty.
+	ColorAuto ColorMode = iota
+	// ColorAlways colorizes the output with ANSI escape codes.
+	ColorAlways
+	// ColorNever never colorizes the output.
+	ColorNever
+)
+
+// ColorMode tells whether a [Renderer] colorizes diagnostics.
+type ColorMode int
+
+// Renderer renders diagnostics as human-readable text, with the source code around them.
+type Renderer struct {
+	// Color tells whether ANSI escape codes are used.
+	Color ColorMode
+	// ASCII makes the output plain ASCII, replacing other characters of the source code and messages with question
+	// marks as wide as the characters, so that underlines stay aligned.
+	ASCII bool
+	// ContextBefore and ContextAfter are the numbers of source lines printed before and after the primary code segment.
+	ContextBefore int
+	ContextAfter  int
+	// TabWidth is the number of columns between tab stops.
+	TabWidth int
+}
+
+// plainRenderer renders [Diagnostic.Error], which is deterministic regardless of the environment.
+var plainRenderer = &Renderer{Color: ColorNever, ContextBefore: 2, ContextAfter: 1, TabWidth: 4}
+
+// NewRenderer creates a [Renderer] with color detected automatically.
+func NewRenderer() *Renderer {
+	r := *plainRenderer
+	r.Color = ColorAuto
+	return &r
+}
+
+// Render writes d into w.
+func (r *Renderer) Render(w io.Writer, d *Diagnostic) error {
+	_, err := io.WriteString(w, r.render(d, r.colorizes(w)))
+	return err
+}
+
+// RenderList writes every [Diagnostic] of l into w, separated by empty lines.
+func (r *Renderer) RenderList(w io.Writer, l List) error {
+	colorizes := r.colorizes(w)
+	for _, d := range l {
+		if _, err := io.WriteString(w, r.render(d, colorizes)+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// colorizes tells whether the output written into w is colorized.
+func (r *Renderer) colorizes(w io.Writer) bool {
+	switch r.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	// NO_COLOR only takes effect if it is not empty, as https://no-color.org specifies.
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	file, isFile := w.(*os.File)
+	return isFile && (isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd()))
+}
+
+// palette is the colors of one rendering, enabled or disabled as a whole.
+type palette struct {
+	severities map[Severity]*color.Color
+	message    *color.Color
+	gutter     *color.Color
+	source     *color.Color
+	label      *color.Color
+}
+
+func newPalette(colorizes bool) *palette {
+	p := &palette{
+		severities: map[Severity]*color.Color{
+			Error:   color.New(color.FgRed, color.Bold),
+			Warning: color.New(color.FgYellow, color.Bold),
+			Note:    color.New(color.FgCyan, color.Bold),
+			Help:    color.New(color.FgGreen, color.Bold),
+		},
+		message: color.New(color.FgHiWhite, color.Bold),
+		gutter:  color.New(color.FgBlue, color.Bold),
+		source:  color.New(color.FgHiBlack),
+		label:   color.New(color.FgBlue),
+	}
+	for _, c := range []*color.Color{p.message, p.gutter, p.source, p.label} {
+		setColor(c, colorizes)
+	}
+	for _, c := range p.severities {
+		setColor(c, colorizes)
+	}
+	return p
+}
+
+func setColor(c *color.Color, enabled bool) {
+	if enabled {
+		c.EnableColor()
+	} else {
+		c.DisableColor()
+	}
+}
+
+func (p *palette) severity(severity Severity) *color.Color {
+	if c, exists := p.severities[severity]; exists {
+		return c
+	}
+	return p.severities[Error]
+}
+
+// rendering is the state of rendering one [Diagnostic].
+type rendering struct {
+	*Renderer
+	*palette
+	builder *strings.Builder
+	d       *Diagnostic
+}
+
+// annotation is an underline of a code segment, either the primary one or a [Label]. The end is exclusive, and equals
+// the start for a single position.
+type annotation struct {
+	start   *Position
+	end     *Position
+	primary bool
+	message string
+}
+
+// multiline tells whether the code segment crosses lines.
+func (a annotation) multiline() bool { return a.end.Line > a.start.Line }
+
+// Error implements the [error] interface, making [Diagnostic] of the [error] type and can be treated as a regular
+// [error]. The text is never colorized; use a [Renderer] for terminals. The source code around the diagnostic is
+// rendered like rustc, with the code after the severity:
+//
+//	error[redeclaration]: variable a is already declared in this scope
+//	 --> main.lox:2:5
+//	  |
+//	1 | var a = 1;
+//	  |     - previously declared here
+//	2 | var a = 2;
+//	  |     ^
+//	  = help: assign to a without var, or give the new variable another name
+func (d *Diagnostic) Error() string {
+	return plainRenderer.render(d, false)
+}
+
+func (r *Renderer) render(d *Diagnostic, colorizes bool) string {
+	g := &rendering{Renderer: r, palette: newPalette(colorizes), builder: new(strings.Builder), d: d}
+
+	heading := d.severity.String()
+	if d.code != "" {
+		heading += "[" + d.code + "]"
+	}
+	g.write(g.severity(d.severity), heading)
+	g.writeln(g.message, g.text(": "+d.message))
+
+	annotations := d.annotations()
+	lines := g.snippetLines(annotations)
+	gutter := 1
+	if len(lines) > 0 {
+		gutter = len(strconv.Itoa(lines[len(lines)-1] + 1))
+	}
+	padding := strings.Repeat(" ", gutter)
+
+	if len(annotations) > 0 {
+		position := annotations[0].start
+		g.writef(g.gutter, "%s--> ", padding)
+		_, _ = fmt.Fprintf(g.builder, "%s:%d:%d\n", g.text(d.source.name), position.Line+1, position.Column+1)
+		g.writeln(g.gutter, padding+" |")
+		g.renderSnippet(annotations, lines, padding)
+	}
+	for _, attachment := range d.attachments {
+		g.writef(g.gutter, "%s = ", padding)
+		g.write(g.severity(attachment.Severity), attachment.Severity.String())
+		_, _ = fmt.Fprintf(g.builder, ": %s\n", g.text(attachment.Message))
+	}
+	return g.builder.String()
+}
+
+// write writes s in color c. The color is applied by [color.Color.Sprint], since the Fprint family of package color
+// also depends on the global [color.NoColor] setting.
+func (g *rendering) write(c *color.Color, s string) { g.builder.WriteString(c.Sprint(s)) }
+
+func (g *rendering) writeln(c *color.Color, s string) {
+	g.write(c, s)
+	g.builder.WriteByte('\n')
+}
+
+func (g *rendering) writef(c *color.Color, format string, a ...any) {
+	g.write(c, fmt.Sprintf(format, a...))
+}
+
+// text prepares s for the output, replacing non-ASCII characters in ASCII mode.
+func (g *rendering) text(s string) string {
+	if !g.ASCII {
+		return s
+	}
+	return toASCII(s)
+}
+
+// annotations returns the primary annotation followed by the labels, or nothing if [d] has no position in a source.
+// Labels are always underlined on their first line.
+func (d *Diagnostic) annotations() []annotation {
+	if d.source == nil {
+		return nil
+	}
+	var primary annotation
+	switch {
+	case d.position != nil:
+		if d.position.Line >= len(d.source.lines) {
+			return nil
+		}
+		primary = annotation{start: d.position, end: d.position, primary: true}
+	case d.span != nil:
+		start, end := d.source.positionsOf(*d.span)
+		primary = annotation{start: start, end: end, primary: true}
+	default:
+		return nil
+	}
+
+	annotations := []annotation{primary}
+	for _, label := range d.labels {
+		start, end := d.source.positionsOf(label.Span)
+		if end.Line > start.Line {
+			end = NewPosition(start.Line, utf8.RuneCountInString(d.source.lines[start.Line]))
+		}
+		annotations = append(annotations, annotation{start: start, end: end, message: label.Message})
+	}
+	return annotations
+}
+
+// positionsOf converts span into the positions of its start and (exclusive) end. A span ending right after a line
+// break ends at the end of the previous line instead.
+func (s *Source) positionsOf(span Span) (*Position, *Position) {
+	start := s.PositionOf(span.Start)
+	end := s.PositionOf(max(span.Start, span.End))
+	if end.Line > start.Line && end.Column == 0 {
+		end = NewPosition(end.Line-1, utf8.RuneCountInString(s.lines[end.Line-1]))
+	}
+	return start, end
+}
+
+// snippetLines returns the sorted line numbers to print. The primary code segment comes with context lines before and
+// after it, while only the first and the last two lines of long segments are printed. Labels only add their own lines.
+func (g *rendering) snippetLines(annotations []annotation) []int {
+	if len(annotations) == 0 {
+		return nil
+	}
+	// A trailing line break does not start another line to show as context.
+	lastLine := len(g.d.source.lines) - 1
+	if lastLine > 0 && g.d.source.lines[lastLine] == "" {
+		lastLine--
+	}
+
+	primary := annotations[0]
+	var lines []int
+	for line := max(0, primary.start.Line-g.ContextBefore); line <= min(primary.start.Line+1, primary.end.Line); line++ {
+		lines = append(lines, line)
+	}
+	for line := max(primary.start.Line, primary.end.Line-1); line <= primary.end.Line; line++ {
+		lines = append(lines, line)
+	}
+	for line := primary.end.Line + 1; line <= min(primary.end.Line+g.ContextAfter, lastLine); line++ {
+		lines = append(lines, line)
+	}
+	for _, a := range annotations[1:] {
+		lines = append(lines, a.start.Line)
+	}
+	slices.Sort(lines)
+	return slices.Compact(lines)
+}
+
+// renderSnippet prints lines with the underlines of annotations below them. Gaps between the printed lines are elided.
+//
+// A primary code segment crossing lines is drawn in a column between the gutter and the source code:
+//
+//	3 |   var x = f(
+//	  |  ___________^
+//	4 | |     1,
+//	5 | | );
+//	  | |_^
+func (g *rendering) renderSnippet(annotations []annotation, lines []int, padding string) {
+	source := g.d.source
+	primary := annotations[0]
+	startsAtIndent := false
+	if primary.multiline() {
+		line := source.lines[primary.start.Line]
+		indent := utf8.RuneCountInString(line) - utf8.RuneCountInString(strings.TrimLeft(line, " \t"))
+		startsAtIndent = primary.start.Column <= indent
+	}
+
+	// marker returns the column of the multi-line segment at line. Below the line when below is true.
+	marker := func(line int, below bool) string {
+		switch {
+		case !primary.multiline():
+			return ""
+		case line < primary.start.Line:
+			return "  "
+		case line == primary.start.Line && !below:
+			if startsAtIndent {
+				return "/ "
+			}
+			return "  "
+		case line == primary.start.Line:
+			if startsAtIndent {
+				return "| "
+			}
+			return "  "
+		case line <= primary.end.Line:
+			return "| "
+		default:
+			return "  "
+		}
+	}
+	underline := func(a annotation, line int) {
+		g.writef(g.gutter, "%s | %s", padding, marker(line, true))
+		text := source.lines[line]
+		start := displayWidth(text, a.start.Column, g.TabWidth)
+		g.builder.WriteString(strings.Repeat(" ", start))
+		width := max(1, displayWidth(text, a.end.Column, g.TabWidth)-start)
+		if a.primary {
+			g.writeln(g.severity(g.d.severity), "^"+strings.Repeat("~", width-1))
+		} else {
+			g.writeln(g.label, strings.Repeat("-", width)+" "+g.text(a.message))
+		}
+	}
+
+	for i, line := range lines {
+		if i > 0 && line > lines[i-1]+1 {
+			g.writeln(g.gutter, "...")
+		}
+		g.writef(g.gutter, "%*d | %s", len(padding), line+1, marker(line, false))
+		g.writeln(g.source, g.text(expandTabs(source.lines[line], g.TabWidth)))
+
+		for _, a := range annotations[1:] {
+			if a.start.Line == line {
+				underline(a, line)
+			}
+		}
+		switch {
+		case !primary.multiline():
+			if primary.start.Line == line {
+				underline(primary, line)
+			}
+		case line == primary.start.Line && !startsAtIndent:
+			g.writef(g.gutter, "%s |  ", padding)
+			start := displayWidth(source.lines[line], primary.start.Column, g.TabWidth)
+			g.writeln(g.severity(g.d.severity), strings.Repeat("_", start+1)+"^")
+		case line == primary.end.Line:
+			g.writef(g.gutter, "%s | |", padding)
+			end := displayWidth(source.lines[line], primary.end.Column, g.TabWidth)
+			g.writeln(g.severity(g.d.severity), strings.Repeat("_", max(1, end))+"^")
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package diagnostic
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestRenderGolden(t *testing.T) {
+	// spanOf returns the span of the first occurrence of text in source, which must exist.
+	spanOf := func(source, text string) Span {
+		start := strings.Index(source, text)
+		if start < 0 {
+			t.Fatalf("%q is not in %q", text, source)
+		}
+		return Span{Start: start, End: start + len(text)}
+	}
+	// headOf returns the span of the first byte of text in source.
+	headOf := func(source, text string) Span {
+		span := spanOf(source, text)
+		return Span{Start: span.Start, End: span.Start + 1}
+	}
+
+	tests := []struct {
+		name     string
+		renderer Renderer
+		source   string
+		build    func(source string) *Diagnostic
+	}{
+		{
+			name:     "tabs",
+			renderer: Renderer{Color: ColorNever, ContextBefore: 2, ContextAfter: 1, TabWidth: 4},
+			source:   "fun f() {\n\tvar a = 1;\n\t\tvar a = 2;\n}\n",
+			build: func(source string) *Diagnostic {
+				return NewDiagnostic("variable a is already declared in this scope").
+					WithCode("redeclaration").
+					AtSpan(headOf(source, "a = 2")).
+					Label(headOf(source, "a = 1"), "previously declared here").
+					Help("assign to a without var, or give the new variable another name")
+			},
+		},
+		{
+			name:     "wide",
+			renderer: Renderer{Color: ColorNever, ContextBefore: 2, ContextAfter: 1, TabWidth: 4},
+			source:   "var 名前 = \"値\";\nprint 名前 + 😀;\n",
+			build: func(source string) *Diagnostic {
+				return NewDiagnostic("unexpected character '😀'").
+					AtSpan(spanOf(source, "😀")).
+					Label(spanOf(source, "名前 +"), "left operand")
+			},
+		},
+		{
+			name:     "wide_ascii",
+			renderer: Renderer{Color: ColorNever, ASCII: true, ContextBefore: 2, ContextAfter: 1, TabWidth: 4},
+			source:   "var 名前 = \"値\";\nprint 名前 + 😀;\n",
+			build: func(source string) *Diagnostic {
+				return NewDiagnostic("unexpected character '😀'").
+					AtSpan(spanOf(source, "😀")).
+					Label(spanOf(source, "名前 +"), "left operand")
+			},
+		},
+		{
+			name:     "multiline",
+			renderer: Renderer{Color: ColorNever, ContextBefore: 2, ContextAfter: 1, TabWidth: 4},
+			source:   "// call\nvar x = f(\n    1,\n    2,\n    3,\n);\nprint x;\n",
+			build: func(source string) *Diagnostic {
+				return NewDiagnostic("cannot have more than 255 arguments").
+					WithCode("limit-exceeded").
+					AtSpan(spanOf(source, "f(\n    1,\n    2,\n    3,\n)")).
+					Note("the arguments are counted by one byte")
+			},
+		},
+		{
+			name:     "multiline_indent",
+			renderer: Renderer{Color: ColorNever, ContextBefore: 2, ContextAfter: 1, TabWidth: 4},
+			source:   "{\n  print 1;\n  print 2;\n}\n",
+			build: func(source string) *Diagnostic {
+				return NewDiagnostic("unreachable code").
+					WithSeverity(Warning).
+					AtSpan(spanOf(source, "print 1;\n  print 2;"))
+			},
+		},
+		{
+			name:     "labels",
+			renderer: Renderer{Color: ColorNever, ContextBefore: 0, ContextAfter: 0, TabWidth: 4},
+			source:   "class A {\n  init() {}\n}\n\n\n\nclass B < A {\n  init() { return super.m(); }\n}\n",
+			build: func(source string) *Diagnostic {
+				return NewDiagnostic("cannot return a value from an initializer").
+					WithCode("return-value-in-initializer").
+					AtSpan(spanOf(source, "super.m()")).
+					Label(spanOf(source, "B"), "in this class").
+					Label(spanOf(source, "init() {}\n}"), "overrides this initializer")
+			},
+		},
+		{
+			name:     "color",
+			renderer: Renderer{Color: ColorAlways, ContextBefore: 2, ContextAfter: 1, TabWidth: 4},
+			source:   "var a = 1;\nvar a = 2;\n",
+			build: func(source string) *Diagnostic {
+				return NewDiagnostic("variable a is already declared in this scope").
+					WithCode("redeclaration").
+					AtSpan(headOf(source, "a = 2")).
+					Label(headOf(source, "a = 1"), "previously declared here")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := test.build(test.source).Attach(NewSource("test.lox", test.source))
+			builder := new(strings.Builder)
+			if err := test.renderer.Render(builder, d); err != nil {
+				t.Fatal(err)
+			}
+			got := builder.String()
+
+			golden := filepath.Join("testdata", test.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("rendered:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
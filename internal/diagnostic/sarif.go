@@ -0,0 +1,158 @@
+package diagnostic
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifStdinURI locates the source code read from the standard input.
+	sarifStdinURI = "file:///dev/stdin"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID           string          `json:"ruleId,omitempty"`
+	Level            string          `json:"level"`
+	Message          sarifMessage    `json:"message"`
+	Locations        []sarifLocation `json:"locations,omitempty"`
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	ID               *int                  `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion has 1-based lines and columns counting Unicode code points, and the end column is the one after the
+// region.
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+	ByteOffset  int `json:"byteOffset"`
+	ByteLength  int `json:"byteLength"`
+}
+
+// WriteSARIF writes [l] as a SARIF 2.1.0 log of a single run of tool. The log is written even if [l] is empty, so that
+// tools consuming it know the run succeeded. Notes and help messages are appended to the message of their result.
+func (l List) WriteSARIF(w io.Writer, tool string) error {
+	run := sarifRun{
+		Tool:       sarifTool{Driver: sarifDriver{Name: tool}},
+		ColumnKind: "unicodeCodePoints",
+		Results:    make([]sarifResult, 0, len(l)),
+	}
+	for _, d := range l {
+		run.Results = append(run.Results, d.toSARIF())
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+func (d *Diagnostic) toSARIF() sarifResult {
+	diag := d.toJSON()
+	message := []string{diag.Message}
+	for _, note := range diag.Notes {
+		message = append(message, note.Severity+": "+note.Message)
+	}
+	result := sarifResult{
+		RuleID:  diag.Code,
+		Level:   sarifLevelOf(d.severity),
+		Message: sarifMessage{Text: strings.Join(message, "\n")},
+	}
+	if diag.Range != nil {
+		result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocationOf(diag.File, *diag.Range)}}
+	}
+	for i, label := range diag.Labels {
+		result.RelatedLocations = append(result.RelatedLocations, sarifLocation{
+			ID:               &i,
+			PhysicalLocation: sarifPhysicalLocationOf(diag.File, label.Range),
+			Message:          &sarifMessage{Text: label.Message},
+		})
+	}
+	return result
+}
+
+func sarifLevelOf(severity Severity) string {
+	switch severity {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sarifPhysicalLocationOf(file string, r jsonRange) sarifPhysicalLocation {
+	return sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: sarifURIOf(file)},
+		Region: sarifRegion{
+			StartLine:   r.Start.Line,
+			StartColumn: r.Start.Column,
+			EndLine:     r.End.Line,
+			EndColumn:   r.End.Column,
+			ByteOffset:  r.Start.Offset,
+			ByteLength:  r.End.Offset - r.Start.Offset,
+		},
+	}
+}
+
+// sarifURIOf converts a file path into a URI reference. Absolute paths become file URIs, and relative paths stay
+// relative, both percent-encoded.
+func sarifURIOf(file string) string {
+	if file == StdinName {
+		return sarifStdinURI
+	}
+	path := filepath.ToSlash(file)
+	if !filepath.IsAbs(file) {
+		return (&url.URL{Path: path}).String()
+	}
+	// Windows paths start with a drive letter, which is the first segment of the URI path.
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
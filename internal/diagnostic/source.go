@@ -1,59 +1,179 @@
 package diagnostic
 
-import "strings"
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// StdinName is the name of a [Source] read from the standard input, which is not a file path.
+const StdinName = "<stdin>"
 
 // Source is a shallow encapsulation of the source code.
 //
-// This struct contains all information needed to display syntax errors friendly.
+// This struct contains all information needed to display syntax errors friendly. Code segments are usually stored as
+// byte offsets into the original content, which [Source.PositionOf] and [Source.OffsetOf] convert from and into
+// [Position].
 type Source struct {
-	name             string
-	text             []rune
-	lines            []string
-	prefixSumLengths []int
+	name    string
+	content string
+	lines   []string
+	// lineStarts are the byte offsets in content where every line starts, in ascending order.
+	lineStarts []int
 }
 
 // NewSource creates a [Source] with a name (conventionally filename) and its content string.
 func NewSource(name, str string) *Source {
-	cleanedText := strings.ReplaceAll(str, "\r", "")
-	text := []rune(cleanedText)
-	lines := strings.Split(cleanedText, "\n")
+	lines := strings.Split(strings.ReplaceAll(str, "\r", ""), "\n")
 
-	accumulatedLength := 0
-	prefixSumLengths := make([]int, len(lines))
-	for index, line := range lines {
-		accumulatedLength += len(line)
-		prefixSumLengths[index] = accumulatedLength
+	lineStarts := make([]int, 1, len(lines))
+	for offset, b := range []byte(str) {
+		if b == '\n' {
+			lineStarts = append(lineStarts, offset+1)
+		}
 	}
 
 	return &Source{
-		name:             name,
-		text:             text,
-		lines:            lines,
-		prefixSumLengths: prefixSumLengths,
+		name:       name,
+		content:    str,
+		lines:      lines,
+		lineStarts: lineStarts,
+	}
+}
+
+// At returns the rune starting at the byte offset n of the original content, or [utf8.RuneError] if n is not the start
+// of a valid rune.
+func (s *Source) At(n int) rune {
+	r, _ := utf8.DecodeRuneInString(s.content[n:])
+	return r
+}
+
+// Len returns the length of the original content in bytes, which is where the spans end at most.
+func (s *Source) Len() int { return len(s.content) }
+
+// Slice returns the original content between the byte offsets start and end, like the text of a [Span].
+func (s *Source) Slice(start, end int) string { return s.content[start:end] }
+
+// LineCount returns the number of lines. A trailing line break starts an empty last line.
+func (s *Source) LineCount() int { return len(s.lines) }
+
+// Line returns the content of the 0-based line without the line break and carriage returns, or an empty string if the
+// line does not exist.
+func (s *Source) Line(line int) string {
+	if line < 0 || line >= len(s.lines) {
+		return ""
+	}
+	return s.lines[line]
+}
+
+// LineSpan returns the byte offsets of the 0-based line in the original content, excluding its line break (including
+// the carriage return before it). Lines out of range are clamped.
+func (s *Source) LineSpan(line int) Span {
+	line = max(0, min(line, len(s.lineStarts)-1))
+	start, end := s.lineStarts[line], len(s.content)
+	if line+1 < len(s.lineStarts) {
+		end = s.lineStarts[line+1] - 1
+	}
+	end = max(start, len(strings.TrimSuffix(s.content[:end], "\r")))
+	return Span{Start: start, End: end}
+}
+
+// PositionOf converts a byte offset in the original content into a [Position], whose column counts runes and ignores
+// carriage returns. Offsets out of the content are clamped, and offsets in the middle of a rune are moved to its start.
+func (s *Source) PositionOf(offset int) *Position {
+	offset = max(0, min(offset, len(s.content)))
+	for offset < len(s.content) && offset > 0 && !utf8.RuneStart(s.content[offset]) {
+		offset--
 	}
+	line := sort.SearchInts(s.lineStarts, offset+1) - 1
+	column := 0
+	for _, r := range s.content[s.lineStarts[line]:offset] {
+		if r != '\r' {
+			column++
+		}
+	}
+	return NewPosition(line, column)
 }
 
-// At returns the rune of the [Source] text at the specific offset.
-func (s *Source) At(n int) rune { return s.text[n] }
+// OffsetOf converts p into a byte offset in the original content, which is the inverse of [Source.PositionOf]. Lines
+// and columns out of range are clamped to the nearest valid offset.
+func (s *Source) OffsetOf(p *Position) int {
+	if p.Line < 0 {
+		return 0
+	}
+	if p.Line >= len(s.lineStarts) {
+		return len(s.content)
+	}
+	span := s.LineSpan(p.Line)
+	column := p.Column
+	for i, r := range s.content[span.Start:span.End] {
+		if column <= 0 {
+			return span.Start + i
+		}
+		if r != '\r' {
+			column--
+		}
+	}
+	return span.End
+}
 
-// Len returns the length of source code (in []rune).
-func (s *Source) Len() int { return len(s.text) }
+// UTF16Column converts the column of p into UTF-16 code units, which is what the Language Server Protocol uses by
+// default.
+func (s *Source) UTF16Column(p *Position) int {
+	column := 0
+	for _, r := range s.lineRunes(p) {
+		column += utf16Length(r)
+	}
+	return column
+}
 
-// Slice returns a source string from a range.
-func (s *Source) Slice(start, end int) string {
-	return string(s.text[start:end])
+// ByteColumn converts the column of p into bytes of UTF-8 (without carriage returns).
+func (s *Source) ByteColumn(p *Position) int {
+	column := 0
+	for _, r := range s.lineRunes(p) {
+		column += utf8.RuneLen(r)
+	}
+	return column
+}
+
+// PositionOfUTF16 creates a [Position] from a column in UTF-16 code units. A column in the middle of a surrogate pair
+// is rounded up to the next rune.
+func (s *Source) PositionOfUTF16(line, column int) *Position {
+	return s.positionOfUnits(line, column, utf16Length)
+}
+
+// PositionOfByte creates a [Position] from a column in bytes. A column in the middle of a rune is rounded up to the
+// next rune.
+func (s *Source) PositionOfByte(line, column int) *Position {
+	return s.positionOfUnits(line, column, utf8.RuneLen)
+}
+
+// lineRunes returns the runes of the line of p before its column.
+func (s *Source) lineRunes(p *Position) []rune {
+	if p.Line < 0 || p.Line >= len(s.lines) {
+		return nil
+	}
+	runes := []rune(s.lines[p.Line])
+	return runes[:max(0, min(p.Column, len(runes)))]
 }
 
-func (s *Source) lengthOfLine(n int) int {
-	if n == 0 {
-		return s.prefixSumLengths[0]
+func (s *Source) positionOfUnits(line, units int, lengthOf func(rune) int) *Position {
+	column := 0
+	if line >= 0 && line < len(s.lines) {
+		for _, r := range s.lines[line] {
+			if units <= 0 {
+				break
+			}
+			units -= lengthOf(r)
+			column++
+		}
 	}
-	return s.prefixSumLengths[n] - s.prefixSumLengths[n-1]
+	return NewPosition(line, column)
 }
 
-func (s *Source) offsetOnLine(index, line int) int {
-	if line == 0 {
-		return index
+func utf16Length(r rune) int {
+	if r >= 0x10000 {
+		return 2
 	}
-	return index - s.prefixSumLengths[line-1]
+	return 1
 }
@@ -0,0 +1,173 @@
+package diagnostic
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+// sourceText has CRLF line breaks, CJK characters of 3 bytes, an emoji of 4 bytes (2 UTF-16 code units), an empty line
+// and no trailing line break. The byte offsets of its lines are 0, 4, 21 and 22.
+const sourceText = "ab\r\n名前 = 😀x;\r\n\nend"
+
+func TestPositionOf(t *testing.T) {
+	src := NewSource("test.lox", sourceText)
+	tests := []struct {
+		name     string
+		offset   int
+		position Position
+	}{
+		{"start", 0, Position{0, 0}},
+		{"carriage return", 2, Position{0, 2}},
+		{"line feed after carriage return", 3, Position{0, 2}},
+		{"line start", 4, Position{1, 0}},
+		{"middle of CJK", 5, Position{1, 0}},
+		{"after CJK", 7, Position{1, 1}},
+		{"emoji", 13, Position{1, 5}},
+		{"middle of emoji", 15, Position{1, 5}},
+		{"after emoji", 17, Position{1, 6}},
+		{"line end", 19, Position{1, 8}},
+		{"empty line", 21, Position{2, 0}},
+		{"last line", 22, Position{3, 0}},
+		{"end", 25, Position{3, 3}},
+		{"after end", 100, Position{3, 3}},
+		{"before start", -1, Position{0, 0}},
+	}
+	for _, test := range tests {
+		if p := src.PositionOf(test.offset); *p != test.position {
+			t.Errorf("%s: PositionOf(%d) = %v, want %v", test.name, test.offset, p, &test.position)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	src := NewSource("test.lox", sourceText)
+	tests := []struct {
+		position Position
+		// utf16Column and byteColumn are the column of position in UTF-16 code units and in bytes.
+		utf16Column, byteColumn int
+	}{
+		{Position{0, 2}, 2, 2},
+		{Position{1, 0}, 0, 0},
+		{Position{1, 1}, 1, 3},
+		{Position{1, 5}, 5, 9},
+		{Position{1, 6}, 7, 13},
+		{Position{1, 8}, 9, 15},
+		{Position{2, 0}, 0, 0},
+		{Position{3, 3}, 3, 3},
+	}
+	for _, test := range tests {
+		p := &test.position
+		if column := src.UTF16Column(p); column != test.utf16Column {
+			t.Errorf("UTF16Column(%v) = %d, want %d", p, column, test.utf16Column)
+		}
+		if column := src.ByteColumn(p); column != test.byteColumn {
+			t.Errorf("ByteColumn(%v) = %d, want %d", p, column, test.byteColumn)
+		}
+		if q := src.PositionOfUTF16(p.Line, test.utf16Column); *q != *p {
+			t.Errorf("PositionOfUTF16(%d, %d) = %v, want %v", p.Line, test.utf16Column, q, p)
+		}
+		if q := src.PositionOfByte(p.Line, test.byteColumn); *q != *p {
+			t.Errorf("PositionOfByte(%d, %d) = %v, want %v", p.Line, test.byteColumn, q, p)
+		}
+	}
+
+	// Columns past the end of the line are clamped by the conversions into code units.
+	if column := src.UTF16Column(NewPosition(1, 100)); column != 9 {
+		t.Errorf("UTF16Column past the line end = %d, want 9", column)
+	}
+}
+
+func TestColumnsInTheMiddleOfRunes(t *testing.T) {
+	src := NewSource("test.lox", sourceText)
+	// Columns in the middle of a rune are rounded up to the next rune.
+	tests := []struct {
+		name     string
+		position *Position
+		want     Position
+	}{
+		{"UTF-16 in surrogate pair", src.PositionOfUTF16(1, 6), Position{1, 6}},
+		{"UTF-16 past line end", src.PositionOfUTF16(1, 100), Position{1, 8}},
+		{"byte in CJK", src.PositionOfByte(1, 1), Position{1, 1}},
+		{"byte in emoji", src.PositionOfByte(1, 11), Position{1, 6}},
+		{"byte past line end", src.PositionOfByte(3, 100), Position{3, 3}},
+		{"line out of range", src.PositionOfByte(9, 3), Position{9, 0}},
+	}
+	for _, test := range tests {
+		if *test.position != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, test.position, &test.want)
+		}
+	}
+}
+
+func TestOffsetOf(t *testing.T) {
+	src := NewSource("test.lox", sourceText)
+	tests := []struct {
+		position Position
+		offset   int
+	}{
+		{Position{0, 0}, 0},
+		{Position{0, 2}, 2},
+		{Position{0, 9}, 2},
+		{Position{1, 1}, 7},
+		{Position{1, 6}, 17},
+		{Position{1, 8}, 19},
+		{Position{2, 5}, 21},
+		{Position{3, 3}, 25},
+		{Position{-1, 3}, 0},
+		{Position{9, 0}, 25},
+	}
+	for _, test := range tests {
+		if offset := src.OffsetOf(&test.position); offset != test.offset {
+			t.Errorf("OffsetOf(%v) = %d, want %d", &test.position, offset, test.offset)
+		}
+	}
+
+	// Every offset starting a rune converts to a position and back, except the line feeds of CRLF line breaks, which
+	// are at the same position as their carriage return.
+	for offset := range len(sourceText) + 1 {
+		if offset < len(sourceText) && !utf8.RuneStart(sourceText[offset]) {
+			continue
+		}
+		want := offset
+		if offset > 0 && offset < len(sourceText) && sourceText[offset-1:offset+1] == "\r\n" {
+			want = offset - 1
+		}
+		p := src.PositionOf(offset)
+		if got := src.OffsetOf(p); got != want {
+			t.Errorf("OffsetOf(PositionOf(%d)) = %d via %v, want %d", offset, got, p, want)
+		}
+	}
+}
+
+func TestLineSpan(t *testing.T) {
+	src := NewSource("test.lox", sourceText)
+	tests := []struct {
+		line int
+		span Span
+		text string
+	}{
+		{0, Span{0, 2}, "ab"},
+		{1, Span{4, 19}, "名前 = 😀x;"},
+		{2, Span{21, 21}, ""},
+		{3, Span{22, 25}, "end"},
+		{-1, Span{0, 2}, "ab"},
+		{9, Span{22, 25}, "end"},
+	}
+	for _, test := range tests {
+		span := src.LineSpan(test.line)
+		if span != test.span {
+			t.Errorf("LineSpan(%d) = %v, want %v", test.line, span, test.span)
+			continue
+		}
+		if text := src.Slice(span.Start, span.End); text != test.text {
+			t.Errorf("LineSpan(%d) covers %q, want %q", test.line, text, test.text)
+		}
+	}
+
+	if count := src.LineCount(); count != 4 {
+		t.Errorf("LineCount() = %d, want 4", count)
+	}
+	if trailing := NewSource("test.lox", "a\n"); trailing.LineCount() != 2 || trailing.LineSpan(1) != (Span{2, 2}) {
+		t.Errorf("a trailing line break must start an empty line, got %d lines", trailing.LineCount())
+	}
+}
@@ -0,0 +1,99 @@
+package diagnostic
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges are the East Asian Wide and Fullwidth blocks, which take two columns in terminals.
+var wideRanges = []struct{ first, last rune }{
+	{0x1100, 0x115F},   // Hangul Jamo initials
+	{0x2E80, 0x303E},   // CJK radicals, Kangxi radicals, CJK symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, Hangul compatibility Jamo, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK unified ideographs extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi syllables and radicals
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Miscellaneous symbols and pictographs, emoticons
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x20000, 0x2FFFD}, // CJK unified ideographs extension B and later
+	{0x30000, 0x3FFFD}, // CJK unified ideographs extension G and later
+}
+
+// runeWidth returns the number of terminal columns taken by r, which is 0 for combining marks and invisible format
+// characters (like zero width joiners), 2 for wide characters, and 1 otherwise.
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, wide := range wideRanges {
+		if r < wide.first {
+			break
+		}
+		if r <= wide.last {
+			return 2
+		}
+	}
+	return 1
+}
+
+// tabWidthAt returns the width of a tab rendered at column width, with tab stops every tabWidth columns.
+func tabWidthAt(width, tabWidth int) int {
+	if tabWidth <= 0 {
+		return 1
+	}
+	return tabWidth - width%tabWidth
+}
+
+// displayWidth returns the width of the first column runes of line when rendered, expanding tabs to the next tab stop.
+func displayWidth(line string, column, tabWidth int) int {
+	width := 0
+	for _, r := range line {
+		if column <= 0 {
+			break
+		}
+		column--
+		if r == '\t' {
+			width += tabWidthAt(width, tabWidth)
+		} else {
+			width += runeWidth(r)
+		}
+	}
+	return width
+}
+
+// expandTabs replaces the tabs of line with spaces, consistently with [displayWidth].
+func expandTabs(line string, tabWidth int) string {
+	var builder strings.Builder
+	width := 0
+	for _, r := range line {
+		if r == '\t' {
+			n := tabWidthAt(width, tabWidth)
+			builder.WriteString(strings.Repeat(" ", n))
+			width += n
+			continue
+		}
+		builder.WriteRune(r)
+		width += runeWidth(r)
+	}
+	return builder.String()
+}
+
+// toASCII replaces every non-ASCII character of s with as many question marks as its width, so that the columns of
+// the remaining characters do not move.
+func toASCII(s string) string {
+	var builder strings.Builder
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			builder.WriteRune(r)
+		} else {
+			builder.WriteString(strings.Repeat("?", runeWidth(r)))
+		}
+	}
+	return builder.String()
+}
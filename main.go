@@ -1,4 +1,80 @@
+// Command clam compiles Lox source code against Mussel backends.
+//
+// Usage:
+//
+//	clam <command> [flags] [file]
+//
+// The source code is read from file, or from the standard input if file is omitted or is "-". Diagnostics are written
+// into the standard error, as colored text or, with -format, as JSON lines or a SARIF log for editors and CI. In the
+// latter formats, nothing else is written into the standard error, and I/O errors are reported as diagnostics too.
 package main
 
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mussel-lox/clam/internal/diagnostic"
+)
+
+const (
+	exitSuccess = iota
+	exitFailure
+	exitUsage
+)
+
+const usage = `usage: clam <command> [flags] [file]
+
+commands:
+  build   compile the source code into Mussel Lox Bytecode
+  check   report diagnostics without generating any code
+  ast     print the syntax tree of the source code
+  disasm  print the generated bytecode in a human-readable form
+
+run 'clam <command> -h' for flags of a command.
+`
+
 func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(os.Stderr, usage)
+		return exitUsage
+	}
+
+	cmd, exists := commands[args[0]]
+	if !exists {
+		if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			_, _ = fmt.Fprint(os.Stdout, usage)
+			return exitSuccess
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "clam: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	opts, err := parseOptions(args[0], args[1:])
+	if err != nil {
+		if errors.Is(err, errHelp) {
+			return exitSuccess
+		}
+		return exitUsage
+	}
+	err = cmd(opts)
+	if err != nil && !errors.Is(err, errCompilation) && opts.format != "text" {
+		// Machine-readable formats must not be followed by plain text, so the error is one of the diagnostics instead.
+		opts.diagnostics.Add(diagnostic.NewDiagnostic(err.Error()).WithCode(codeIO))
+		err = errCompilation
+	}
+	if writeErr := opts.writeDiagnostics(os.Stderr); writeErr != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "clam:", writeErr)
+	}
+	if err != nil {
+		if !errors.Is(err, errCompilation) {
+			_, _ = fmt.Fprintln(os.Stderr, "clam:", err)
+		}
+		return exitFailure
+	}
+	return exitSuccess
 }
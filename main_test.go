@@ -0,0 +1,181 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// runWith runs the command line args with stdin as the standard input, and returns the exit code with everything
+// written into the standard output and the standard error.
+func runWith(t *testing.T, args []string, stdin string) (int, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	open := func(name, content string) *os.File {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		file, err := os.OpenFile(path, os.O_RDWR, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = file.Close() })
+		return file
+	}
+	read := func(file *os.File) string {
+		content, err := os.ReadFile(file.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+
+	stdinFile, stdoutFile, stderrFile := open("stdin", stdin), open("stdout", ""), open("stderr", "")
+	savedStdin, savedStdout, savedStderr := os.Stdin, os.Stdout, os.Stderr
+	os.Stdin, os.Stdout, os.Stderr = stdinFile, stdoutFile, stderrFile
+	code := run(args)
+	os.Stdin, os.Stdout, os.Stderr = savedStdin, savedStdout, savedStderr
+	return code, read(stdoutFile), read(stderrFile)
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "test.lox")
+	if err := os.WriteFile(file, []byte("print 1;"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.lox")
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		code  int
+		// stdout and stderr are expected in the standard output and the standard error. An empty one is expected to
+		// be empty.
+		stdout, stderr string
+	}{
+		{name: "no arguments", code: exitUsage, stderr: "usage: clam <command>"},
+		{name: "help", args: []string{"help"}, code: exitSuccess, stdout: "usage: clam <command>"},
+		{name: "unknown command", args: []string{"run"}, code: exitUsage, stderr: `clam: unknown command "run"`},
+		{name: "command help", args: []string{"check", "-h"}, code: exitSuccess, stderr: "usage: clam check [flags] [file]"},
+		{name: "unknown flag", args: []string{"check", "-x"}, code: exitUsage, stderr: "flag provided but not defined: -x"},
+		{
+			name:   "invalid color",
+			args:   []string{"check", "-color=sometimes"},
+			code:   exitUsage,
+			stderr: `clam: invalid color mode "sometimes"`,
+		},
+		{
+			name:   "invalid format",
+			args:   []string{"check", "-format=xml"},
+			code:   exitUsage,
+			stderr: `clam: invalid diagnostic format "xml"`,
+		},
+		{name: "too many files", args: []string{"check", file, file}, code: exitUsage, stderr: "clam: too many input files"},
+		{name: "check stdin", args: []string{"check"}, stdin: "print 1;", code: exitSuccess},
+		{name: "check file", args: []string{"check", file}, code: exitSuccess},
+		{
+			name:   "disasm stdin",
+			args:   []string{"disasm", "-"},
+			stdin:  "print 1;",
+			code:   exitSuccess,
+			stdout: "== <script> ==",
+		},
+		{name: "ast file", args: []string{"ast", file}, code: exitSuccess, stdout: "Print"},
+		{
+			name:   "syntax error",
+			args:   []string{"check", "-color=never"},
+			stdin:  "print 1",
+			code:   exitFailure,
+			stderr: "error[syntax-error]",
+		},
+		{
+			name:   "syntax error in JSON",
+			args:   []string{"check", "-format=json"},
+			stdin:  "print 1",
+			code:   exitFailure,
+			stderr: `"file":"<stdin>"`,
+		},
+		{
+			name:   "resolution error",
+			args:   []string{"build", "-color=never"},
+			stdin:  "return 1;",
+			code:   exitFailure,
+			stderr: "error[return-outside-function]",
+		},
+		{name: "missing file", args: []string{"check", missing}, code: exitFailure, stderr: "clam: open " + missing},
+		{
+			// Machine-readable formats report I/O errors as diagnostics, without any plain text.
+			name:   "missing file in SARIF",
+			args:   []string{"check", "-format=sarif", missing},
+			code:   exitFailure,
+			stderr: `"ruleId": "io-error"`,
+		},
+		{
+			name:   "output into missing directory",
+			args:   []string{"disasm", "-o", filepath.Join(missing, "out.txt"), file},
+			code:   exitFailure,
+			stderr: "clam: open " + missing,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, stdout, stderr := runWith(t, test.args, test.stdin)
+			if code != test.code {
+				t.Errorf("got exit code %d, want %d\nstderr:\n%s", code, test.code, stderr)
+			}
+			for _, output := range []struct{ name, got, want string }{
+				{"standard output", stdout, test.stdout},
+				{"standard error", stderr, test.stderr},
+			} {
+				if output.want == "" && output.got != "" || !strings.Contains(output.got, output.want) {
+					t.Errorf("got %s:\n%s\nwant %q", output.name, output.got, output.want)
+				}
+			}
+			machineReadable := slices.ContainsFunc(test.args, func(arg string) bool {
+				return arg == "-format=json" || arg == "-format=sarif"
+			})
+			if machineReadable && strings.Contains(stderr, "clam:") {
+				t.Errorf("got plain text in a machine-readable format:\n%s", stderr)
+			}
+		})
+	}
+}
+
+func TestRunOutput(t *testing.T) {
+	dir := t.TempDir()
+	for _, command := range []string{"build", "ast", "disasm"} {
+		output := filepath.Join(dir, command+".out")
+		code, stdout, stderr := runWith(t, []string{command, "-o", output}, "print 1;")
+		if code != exitSuccess {
+			t.Fatalf("%s: got exit code %d, want %d\nstderr:\n%s", command, code, exitSuccess, stderr)
+		}
+		content, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatalf("%s: %v", command, err)
+		}
+
+		// The output file holds exactly what is written into the standard output without -o.
+		_, want, _ := runWith(t, []string{command}, "print 1;")
+		if string(content) != want || want == "" {
+			t.Errorf("%s: got output file\n%q\nwant\n%q", command, content, want)
+		}
+		if stdout != "" {
+			t.Errorf("%s: got standard output %q, want nothing with -o", command, stdout)
+		}
+	}
+
+	// A failed compilation does not create the output file.
+	output := filepath.Join(dir, "failed.out")
+	if code, _, _ := runWith(t, []string{"build", "-o", output}, "print 1"); code != exitFailure {
+		t.Errorf("got exit code %d for a failed build, want %d", code, exitFailure)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("got output file of a failed build, want none: %v", err)
+	}
+}
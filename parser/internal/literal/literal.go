@@ -0,0 +1,87 @@
+// Package literal decodes the number and string literals of Lox, shared by the parser implementations.
+package literal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseNumber converts the text of a number literal, which consists of decimal digits with an optional fraction.
+func ParseNumber(text string) (float64, error) {
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, errors.New("number literal is out of range")
+		}
+		return 0, errors.New("invalid number literal")
+	}
+	return value, nil
+}
+
+// Unquote removes the surrounding double quotes of a string literal and decodes its escape sequences:
+//
+//	\n \t \" \\ \u{hex}
+//
+// If an escape sequence is invalid, the index of its backslash in quoted is returned along with the error.
+func Unquote(quoted string) (string, int, error) {
+	content := quoted[1 : len(quoted)-1]
+	if !strings.ContainsRune(content, '\\') {
+		return content, 0, nil
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(content); {
+		if content[i] != '\\' {
+			builder.WriteByte(content[i])
+			i++
+			continue
+		}
+		r, length, err := unescape(content[i:])
+		if err != nil {
+			return "", i + 1, err
+		}
+		builder.WriteRune(r)
+		i += length
+	}
+	return builder.String(), 0, nil
+}
+
+// unescape decodes the escape sequence at the beginning of text, returning the rune with the length of the sequence.
+func unescape(text string) (rune, int, error) {
+	if len(text) < 2 {
+		return 0, 0, errors.New("invalid escape sequence")
+	}
+	switch text[1] {
+	case 'n':
+		return '\n', 2, nil
+	case 't':
+		return '\t', 2, nil
+	case '"':
+		return '"', 2, nil
+	case '\\':
+		return '\\', 2, nil
+	case 'u':
+		end := strings.IndexByte(text, '}')
+		if !strings.HasPrefix(text[2:], "{") || end < 0 {
+			return 0, 0, errors.New("expected braced hexadecimal digits in unicode escape sequence")
+		}
+		digits := text[3:end]
+		if len(digits) == 0 || len(digits) > 6 {
+			return 0, 0, errors.New("unicode escape sequence must have 1 to 6 hexadecimal digits")
+		}
+		code, err := strconv.ParseUint(digits, 16, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid hexadecimal digits %q in unicode escape sequence", digits)
+		}
+		if !utf8.ValidRune(rune(code)) {
+			return 0, 0, fmt.Errorf("invalid unicode code point U+%X", code)
+		}
+		return rune(code), end + 1, nil
+	default:
+		r, _ := utf8.DecodeRuneInString(text[1:])
+		return 0, 0, fmt.Errorf("unknown escape sequence \\%c", r)
+	}
+}
@@ -0,0 +1,43 @@
+// Package syntax contains the rules of Lox syntax beyond the grammar, shared by the parser implementations so that they
+// build the same syntax tree and report the same errors.
+//
+// Spans of nodes cover their tokens without the whitespaces and comments around them. Parentheses around an
+// expression are not part of its span, except in call chains: every invocation and property access starts where the
+// chain starts, including the parentheses around its primary expression, like `(f)(x).y`.
+package syntax
+
+import "github.com/mussel-lox/clam/ast"
+
+// Codes of the diagnostics reported by the parsers, set by [diagnostic.Diagnostic.WithCode].
+const (
+	// CodeLexical is the code of malformed tokens, like unterminated strings and comments, invalid escape sequences,
+	// numbers out of range and invalid UTF-8 encoding.
+	CodeLexical = "lexical-error"
+	// CodeSyntax is the code of tokens unexpected by the grammar.
+	CodeSyntax = "syntax-error"
+	// CodeInvalidAssignmentTarget is the code of assignments to anything but a variable or a property.
+	CodeInvalidAssignmentTarget = "invalid-assignment-target"
+	// CodeInternal is the code of errors caused by bugs of the parsers.
+	CodeInternal = "internal-error"
+)
+
+// InvalidAssignmentTarget is the message of the error reported over a target rejected by [IsAssignable].
+const InvalidAssignmentTarget = "invalid assignment target"
+
+// IsAssignable tells whether target can be assigned by an assignment starting at the byte offset start. The grammar
+// accepts any expression before the equal sign, but only variables and properties (except those of super) can be
+// assigned, without any parentheses around them. A parenthesized target starts after the assignment.
+func IsAssignable(start int, target ast.Expression) bool {
+	if target.Location().Start != start {
+		return false
+	}
+	switch t := target.(type) {
+	case *ast.Identifier:
+		return true
+	case *ast.PropertyAccessExpression:
+		_, isSuper := t.Target.(ast.Super)
+		return !isSuper
+	default:
+		return false
+	}
+}
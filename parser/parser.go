@@ -4,10 +4,14 @@ package parser
 
 import (
 	"github.com/mussel-lox/clam/ast"
-	"github.com/mussel-lox/clam/parser/peg"
+	"github.com/mussel-lox/clam/parser/pratt"
 )
 
-// Parse is the only API that is stable. The internal implementation (including package peg) may be changed any time.
+// Parse is the only API that is stable. The internal implementation (including package pratt and peg) may be changed
+// any time. Both produce the same syntax tree, while the hand-written package pratt is used for its speed and more
+// precise error messages.
+//
+// If the source code has syntax errors, the declarations parsed successfully are returned along with the error.
 func Parse(filename, source string) ([]ast.Declaration, error) {
-	return peg.ParseWithDiagnostic(filename, source)
+	return pratt.Parse(filename, source)
 }
@@ -0,0 +1,811 @@
+package parser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+	"github.com/mussel-lox/clam/parser"
+	"github.com/mussel-lox/clam/parser/internal/syntax"
+	"github.com/mussel-lox/clam/parser/peg"
+	"github.com/mussel-lox/clam/parser/pratt"
+)
+
+// backends are the parser implementations, which must agree on every input.
+var backends = []struct {
+	name  string
+	parse func(filename, source string) ([]ast.Declaration, error)
+}{
+	{"peg", peg.ParseWithDiagnostic},
+	{"pratt", pratt.Parse},
+}
+
+// forEachBackend runs test as a subtest for each backend.
+func forEachBackend(t *testing.T, test func(t *testing.T, parse func(filename, source string) ([]ast.Declaration, error))) {
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) { test(t, backend.parse) })
+	}
+}
+
+var keywords = []string{
+	"and", "class", "else", "false", "for", "fun", "if", "nil", "or",
+	"print", "return", "super", "this", "true", "var", "while",
+}
+
+func TestKeywordPrefixedIdentifiers(t *testing.T) {
+	names := []string{
+		"android", "classy", "elsewhere", "falsey", "format", "funny", "iffy", "nilCount", "order",
+		"printer", "returned", "superb", "thistle", "trueness", "variable", "whiler",
+		"if_", "var1", "_while", "Class", "NIL",
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, name := range names {
+			source := fmt.Sprintf("var %s = %s;", name, name)
+			program, err := parse("test.lox", source)
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", source, err)
+				continue
+			}
+			decl, ok := program[0].(*ast.VarDeclaration)
+			if !ok {
+				t.Errorf("%q: got %T, want *ast.VarDeclaration", source, program[0])
+				continue
+			}
+			if decl.Name.Name != name {
+				t.Errorf("%q: got name %q, want %q", source, decl.Name.Name, name)
+			}
+			want := ast.Span{Start: len("var "), End: len("var ") + len(name)}
+			if decl.Name.Span != want {
+				t.Errorf("%q: got name span %v, want %v", source, decl.Name.Span, want)
+			}
+			if reference, ok := decl.Initializer.(*ast.Identifier); !ok || reference.Name != name {
+				t.Errorf("%q: got initializer %#v, want identifier %s", source, decl.Initializer, name)
+			}
+		}
+	})
+}
+
+func TestKeywordsAreReserved(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, keyword := range keywords {
+			for _, source := range []string{
+				fmt.Sprintf("var %s = 1;", keyword),
+				fmt.Sprintf("fun %s() {}", keyword),
+				fmt.Sprintf("class %s {}", keyword),
+			} {
+				if _, err := parse("test.lox", source); err == nil {
+					t.Errorf("%q: keyword %s is accepted as a name", source, keyword)
+				}
+			}
+		}
+	})
+}
+
+func TestKeywordsAsWholeWords(t *testing.T) {
+	tests := []struct {
+		source string
+		check  func(program []ast.Declaration) bool
+	}{
+		{"print true and false or nil;", func(program []ast.Declaration) bool {
+			print, ok := statementOf(program).(*ast.PrintStatement)
+			if !ok {
+				return false
+			}
+			or, isBinary := print.Expression.(*ast.BinaryExpression)
+			return isBinary && or.Operator == ast.BinopLogicalOr
+		}},
+		{"if (x) print 1; else print 2;", func(program []ast.Declaration) bool {
+			stmt, ok := statementOf(program).(*ast.IfStatement)
+			return ok && stmt.Otherwise != nil
+		}},
+		{"while (x) return;", func(program []ast.Declaration) bool {
+			stmt, ok := statementOf(program).(*ast.WhileStatement)
+			if !ok {
+				return false
+			}
+			_, isReturn := stmt.Body.(*ast.ReturnStatement)
+			return isReturn
+		}},
+		{"for (;;) print this;", func(program []ast.Declaration) bool {
+			_, ok := statementOf(program).(*ast.ForStatement)
+			return ok
+		}},
+		{"class A < B { f() { return super.f; } }", func(program []ast.Declaration) bool {
+			class, ok := program[0].(*ast.ClassDeclaration)
+			return ok && class.Baseclass != nil && len(class.Methods) == 1
+		}},
+		{"fun f() {} var v;", func(program []ast.Declaration) bool {
+			if len(program) != 2 {
+				return false
+			}
+			_, isFun := program[0].(*ast.FunDeclaration)
+			_, isVar := program[1].(*ast.VarDeclaration)
+			return isFun && isVar
+		}},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			program, err := parse("test.lox", test.source)
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", test.source, err)
+				continue
+			}
+			if !test.check(program) {
+				t.Errorf("%q: unexpected syntax tree", test.source)
+			}
+		}
+	})
+}
+
+// statementOf returns the statement of the first declaration in program, or nil if it is not a statement.
+func statementOf(program []ast.Declaration) ast.Statement {
+	if decl, ok := program[0].(*ast.StatementDeclaration); ok {
+		return decl.Statement
+	}
+	return nil
+}
+
+// FuzzParse checks that no input panics the parsers. Every syntax error must be reported as a diagnostic instead.
+func FuzzParse(f *testing.F) {
+	seeds := []string{
+		"print 1 }",
+		"@",
+		"class A { m() } class B {}",
+		"\xff",
+		"print \"\xff\";",
+		"// \xff\n/* \xfe */",
+		strings.Repeat("9", 400) + ";",
+		"print 1e999;",
+		"var a = \"unterminated",
+		"/* unterminated",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, source string) {
+		// The PEG backend may backtrack for a long time on large inputs, which is not what this test is about.
+		if len(source) > 1024 {
+			t.Skip()
+		}
+		for _, backend := range backends {
+			backend.parse("fuzz.lox", source)
+		}
+		parser.Parse("fuzz.lox", source)
+	})
+}
+
+func TestClassDeclarations(t *testing.T) {
+	// method is the expected method of a class, with its span given by the source code it covers.
+	type method struct {
+		text       string
+		parameters []string
+	}
+	tests := []struct {
+		source    string
+		baseclass string
+		methods   []method
+		// superCall is the text of the invocation of super in the first statement of the first method, if any.
+		superCall string
+	}{
+		{source: "class A {}"},
+		{
+			source:  "class A { m() {} }",
+			methods: []method{{text: "m() {}"}},
+		},
+		{
+			source: "class Point { init(x, y) { this.x = x; this.y = y; } norm() { return this.x * this.x + this.y * this.y; } }",
+			methods: []method{
+				{text: "init(x, y) { this.x = x; this.y = y; }", parameters: []string{"x", "y"}},
+				{text: "norm() { return this.x * this.x + this.y * this.y; }"},
+			},
+		},
+		{
+			source:    "class B < A {}",
+			baseclass: "A",
+		},
+		{
+			source:    "class B < A {\n  init(a) {\n    super.init(a);\n  }\n}",
+			baseclass: "A",
+			methods:   []method{{text: "init(a) {\n    super.init(a);\n  }", parameters: []string{"a"}}},
+			superCall: "super.init(a)",
+		},
+		{
+			source:    "class B < A { m() { return super.m(); } init() {} }",
+			baseclass: "A",
+			methods:   []method{{text: "m() { return super.m(); }"}, {text: "init() {}"}},
+			superCall: "super.m()",
+		},
+	}
+
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			program, err := parse("test.lox", test.source)
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", test.source, err)
+				continue
+			}
+			class, ok := program[0].(*ast.ClassDeclaration)
+			if !ok {
+				t.Errorf("%q: got %T, want *ast.ClassDeclaration", test.source, program[0])
+				continue
+			}
+			if text := textOf(test.source, class.Span); text != test.source {
+				t.Errorf("%q: got class span over %q", test.source, text)
+			}
+			switch {
+			case test.baseclass == "" && class.Baseclass != nil:
+				t.Errorf("%q: got baseclass %s, want none", test.source, class.Baseclass.Name)
+			case test.baseclass != "" && (class.Baseclass == nil || class.Baseclass.Name != test.baseclass):
+				t.Errorf("%q: got baseclass %v, want %s", test.source, class.Baseclass, test.baseclass)
+			}
+
+			if len(class.Methods) != len(test.methods) {
+				t.Errorf("%q: got %d methods, want %d", test.source, len(class.Methods), len(test.methods))
+				continue
+			}
+			for i, want := range test.methods {
+				got := class.Methods[i]
+				if text := textOf(test.source, got.Span); text != want.text {
+					t.Errorf("%q: got method %d over %q, want %q", test.source, i, text, want.text)
+				}
+				if text := textOf(test.source, got.Name.Span); text != got.Name.Name {
+					t.Errorf("%q: got method name %s over %q", test.source, got.Name.Name, text)
+				}
+				var parameters []string
+				for _, parameter := range got.Parameters {
+					parameters = append(parameters, parameter.Name)
+				}
+				if fmt.Sprint(parameters) != fmt.Sprint(want.parameters) {
+					t.Errorf("%q: got parameters %v of method %d, want %v", test.source, parameters, i, want.parameters)
+				}
+			}
+
+			if test.superCall != "" {
+				call, ok := superCallOf(class.Methods[0])
+				if !ok {
+					t.Errorf("%q: no invocation of super in the first method", test.source)
+				} else if text := textOf(test.source, call.Span); text != test.superCall {
+					t.Errorf("%q: got invocation of super over %q, want %q", test.source, text, test.superCall)
+				}
+			}
+		}
+	})
+}
+
+func TestClassRecovery(t *testing.T) {
+	tests := []struct {
+		source string
+		// methods are the names of the methods parsed in every class.
+		methods [][]string
+	}{
+		{"class A { m() } class B {}", [][]string{nil, nil}},
+		{"class A { m() n() {} } class B { o() {} }", [][]string{nil, {"o"}}},
+		{"class A { m(a,) {} n() {} }", [][]string{{"n"}}},
+		{"class A { m() { print; } n() {} }", [][]string{{"m", "n"}}},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			program, err := parse("test.lox", test.source)
+			if err == nil {
+				t.Errorf("%q: no error reported", test.source)
+			}
+			var methods [][]string
+			for _, decl := range program {
+				class, ok := decl.(*ast.ClassDeclaration)
+				if !ok {
+					t.Errorf("%q: got %T, want *ast.ClassDeclaration", test.source, decl)
+					continue
+				}
+				var names []string
+				for _, method := range class.Methods {
+					names = append(names, method.Name.Name)
+				}
+				methods = append(methods, names)
+			}
+			if fmt.Sprint(methods) != fmt.Sprint(test.methods) {
+				t.Errorf("%q: got methods %v, want %v", test.source, methods, test.methods)
+			}
+		}
+	})
+}
+
+func TestStringLiterals(t *testing.T) {
+	tests := []struct {
+		literal string
+		value   string
+	}{
+		{`" a "`, " a "},
+		{`""`, ""},
+		{`"\n\t\"\\"`, "\n\t\"\\"},
+		{`"\u{1F600} \u{e9}"`, "😀 é"},
+		{"\"two\nlines\"", "two\nlines"},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			source := "print " + test.literal + ";"
+			program, err := parse("test.lox", source)
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", source, err)
+				continue
+			}
+			print, ok := statementOf(program).(*ast.PrintStatement)
+			if !ok {
+				t.Errorf("%q: got %T, want *ast.PrintStatement", source, statementOf(program))
+				continue
+			}
+			literal, ok := print.Expression.(ast.StringLiteral)
+			if !ok || literal.Value != test.value {
+				t.Errorf("%q: got %#v, want string literal %q", source, print.Expression, test.value)
+			}
+			if text := textOf(source, print.Expression.Location()); text != test.literal {
+				t.Errorf("%q: got span over %q, want the whole literal", source, text)
+			}
+		}
+	})
+}
+
+func TestStringLiteralErrors(t *testing.T) {
+	tests := []struct {
+		source  string
+		message string
+		offset  int
+	}{
+		{`print "a\qb";`, `unknown escape sequence \q`, len(`print "a`)},
+		{`print "\u{110000}";`, "invalid unicode code point U+110000", len(`print "`)},
+		{`print "\u{D800}";`, "invalid unicode code point U+D800", len(`print "`)},
+		{`print "\u{}";`, "unicode escape sequence must have 1 to 6 hexadecimal digits", len(`print "`)},
+		// An unterminated string runs to the end of the source, where nothing else is reported.
+		{`print "abc`, "unterminated string", len(`print `)},
+		{"print \"abc\n;", "unterminated string", len(`print `)},
+		{`var s = "abc\"`, "unterminated string", len(`var s = `)},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			_, err := parse("test.lox", test.source)
+			diagnostics := diagnosticsOf(t, err)
+			if len(diagnostics) != 1 {
+				t.Errorf("%q: got %d errors, want 1:\n%v", test.source, len(diagnostics), err)
+				continue
+			}
+			d := diagnostics[0]
+			if d.Code() != syntax.CodeLexical || d.Message() != test.message {
+				t.Errorf("%q: got [%s] %q, want [%s] %q", test.source, d.Code(), d.Message(), syntax.CodeLexical, test.message)
+			}
+			if span, _ := d.Span(); span != (diagnostic.Span{Start: test.offset, End: test.offset}) {
+				t.Errorf("%q: got span %v, want offset %d", test.source, span, test.offset)
+			}
+		}
+	})
+}
+
+func TestComments(t *testing.T) {
+	tests := []struct {
+		source    string
+		statement string
+	}{
+		{"print 1; // comment", "print 1;"},
+		{"// comment\nprint 1;", "print 1;"},
+		{"/* comment */ print 1;", "print 1;"},
+		{"/* outer /* inner */ still outer */ print 1;", "print 1;"},
+		{"/* /* /* deep */ */ */ print /* between */ 1 /**/;", "print /* between */ 1 /**/;"},
+		{"/* line\n * comment\n */print 1;// end", "print 1;"},
+		{"/* a // line comment does not hide the end */ print 1;", "print 1;"},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			program, err := parse("test.lox", test.source)
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", test.source, err)
+				continue
+			}
+			if len(program) != 1 || textOf(test.source, program[0].Location()) != test.statement {
+				t.Errorf("%q: got %d declarations, want only %q", test.source, len(program), test.statement)
+			}
+		}
+	})
+}
+
+func TestUnterminatedComments(t *testing.T) {
+	tests := []struct {
+		source string
+		offset int
+	}{
+		{"print 1; /* open", len("print 1; ")},
+		// The inner comment is closed, but not the outer one.
+		{"/* outer /* inner */ print 1;", 0},
+		{"print 1 /* open", len("print 1 ")},
+		{"print 1;\n/* /* */\n", len("print 1;\n")},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			_, err := parse("test.lox", test.source)
+			diagnostics := diagnosticsOf(t, err)
+			if len(diagnostics) != 1 {
+				t.Errorf("%q: got %d errors, want 1:\n%v", test.source, len(diagnostics), err)
+				continue
+			}
+			d := diagnostics[0]
+			if d.Code() != syntax.CodeLexical || d.Message() != "unterminated block comment" {
+				t.Errorf("%q: got [%s] %q, want an unterminated block comment", test.source, d.Code(), d.Message())
+			}
+			if span, _ := d.Span(); span != (diagnostic.Span{Start: test.offset, End: test.offset}) {
+				t.Errorf("%q: got span %v, want offset %d", test.source, span, test.offset)
+			}
+		}
+	})
+}
+
+func TestAssignmentTargets(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		source := "a.b.c = x;"
+		program, err := parse("test.lox", source)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", source, err)
+		}
+		stmt, ok := statementOf(program).(*ast.ExpressionStatement)
+		if !ok {
+			t.Fatalf("%q: got %T, want *ast.ExpressionStatement", source, statementOf(program))
+		}
+		assignment, ok := stmt.Expression.(*ast.AssignmentExpression)
+		if !ok {
+			t.Fatalf("%q: got %T, want *ast.AssignmentExpression", source, stmt.Expression)
+		}
+		if value, ok := assignment.Value.(*ast.Identifier); !ok || value.Name != "x" {
+			t.Errorf("%q: got value %#v, want x", source, assignment.Value)
+		}
+		// The target is c of the property b of a, where every property access starts with the chain.
+		outer, ok := assignment.Target.(*ast.PropertyAccessExpression)
+		if !ok || outer.Property.Name != "c" || textOf(source, outer.Span) != "a.b.c" {
+			t.Fatalf("%q: got target %#v, want a.b.c", source, assignment.Target)
+		}
+		inner, ok := outer.Target.(*ast.PropertyAccessExpression)
+		if !ok || inner.Property.Name != "b" || textOf(source, inner.Span) != "a.b" {
+			t.Fatalf("%q: got target of c %#v, want a.b", source, outer.Target)
+		}
+		if a, ok := inner.Target.(*ast.Identifier); !ok || a.Name != "a" {
+			t.Errorf("%q: got target of b %#v, want a", source, inner.Target)
+		}
+	})
+}
+
+func TestInvalidAssignmentTargets(t *testing.T) {
+	tests := []struct {
+		source string
+		target string
+	}{
+		// A parenthesized variable is an expression, reported without the parentheses.
+		{"(a) = 1;", "a"},
+		{"a() = 2;", "a()"},
+		{"a.b() = 3;", "a.b()"},
+		{"a + b = 4;", "a + b"},
+		{"-a = 5;", "-a"},
+		{"(a.b) = 6;", "a.b"},
+		{"class A < B { m() { super.m = 7; } }", "super.m"},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			_, err := parse("test.lox", test.source)
+			diagnostics := diagnosticsOf(t, err)
+			if len(diagnostics) != 1 {
+				t.Errorf("%q: got %d errors, want 1:\n%v", test.source, len(diagnostics), err)
+				continue
+			}
+			d := diagnostics[0]
+			if d.Code() != syntax.CodeInvalidAssignmentTarget || d.Message() != syntax.InvalidAssignmentTarget {
+				t.Errorf("%q: got [%s] %q, want an invalid assignment target", test.source, d.Code(), d.Message())
+			}
+			start := strings.Index(test.source, test.target)
+			want := diagnostic.Span{Start: start, End: start + len(test.target)}
+			if span, _ := d.Span(); span != want {
+				t.Errorf("%q: got span %v, want %v over %q", test.source, span, want, test.target)
+			}
+		}
+	})
+}
+
+func TestUnexpectedTokens(t *testing.T) {
+	tests := []struct {
+		source  string
+		message string
+		// token is the text covered by the diagnostic, at its last occurrence in source.
+		token string
+	}{
+		{"}", `unexpected token "}"`, "}"},
+		{"print 1; }", `unexpected token "}"`, "}"},
+		{"{ print 1; } }", `unexpected token "}"`, "}"},
+		{";", `unexpected token ";"`, ";"},
+		{"@", "unexpected character '@', expected declaration or statement", "@"},
+		{"print 1;\n@ print 2;", "unexpected character '@', expected declaration or statement", "@"},
+		{"{ 😀 }", "unexpected character '😀', expected declaration or statement", "😀"},
+		{"else print 1;", `unexpected keyword "else", expected declaration or statement`, "else"},
+		{"fun f() { ) }", `unexpected token ")", expected declaration or statement`, ")"},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			_, err := parse("test.lox", test.source)
+			diagnostics := diagnosticsOf(t, err)
+			if len(diagnostics) != 1 {
+				t.Errorf("%q: got %d errors, want 1:\n%v", test.source, len(diagnostics), err)
+				continue
+			}
+			d := diagnostics[0]
+			if d.Code() != syntax.CodeSyntax || d.Message() != test.message {
+				t.Errorf("%q: got [%s] %q, want [%s] %q", test.source, d.Code(), d.Message(), syntax.CodeSyntax, test.message)
+			}
+			start := strings.LastIndex(test.source, test.token)
+			want := diagnostic.Span{Start: start, End: start + len(test.token)}
+			if span, _ := d.Span(); span != want {
+				t.Errorf("%q: got span %v, want %v", test.source, span, want)
+			}
+		}
+	})
+}
+
+func TestForInitializers(t *testing.T) {
+	tests := []struct {
+		source      string
+		initializer string
+	}{
+		{"for (var i = 0; i < 1; i = i + 1) print i;", "*ast.VarDeclaration var i = 0;"},
+		// An assignment used to be dropped, since it is neither a declaration nor an expression.
+		{"for (i = 0; i < 1; i = i + 1) print i;", "*ast.ExpressionStatement i = 0;"},
+		{"for (; i < 1;) print i;", "<nil>"},
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, test := range tests {
+			program, err := parse("test.lox", test.source)
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", test.source, err)
+				continue
+			}
+			loop, ok := statementOf(program).(*ast.ForStatement)
+			if !ok {
+				t.Errorf("%q: got %T, want *ast.ForStatement", test.source, statementOf(program))
+				continue
+			}
+			got := "<nil>"
+			if loop.Initializer != nil {
+				got = fmt.Sprintf("%T %s", loop.Initializer, textOf(test.source, loop.Initializer.Location()))
+			}
+			if got != test.initializer {
+				t.Errorf("%q: got initializer %s, want %s", test.source, got, test.initializer)
+			}
+		}
+	})
+}
+
+func TestBrokenFunctionHeaders(t *testing.T) {
+	sources := []string{
+		"fun f(a,) {}",
+		"fun f(a b) { print a; }",
+		"fun () { print 1; }",
+		"fun f( { print 1; }",
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, source := range sources {
+			program, err := parse("test.lox", source)
+			if len(program) != 0 {
+				t.Errorf("%q: got %d declarations, want none", source, len(program))
+			}
+			// The body is skipped with the header, so its closing brace is not reported as stray.
+			if diagnostics := diagnosticsOf(t, err); len(diagnostics) != 1 {
+				t.Errorf("%q: got %d errors, want 1:\n%v", source, len(diagnostics), err)
+			}
+		}
+
+		source := "fun outer() { fun f(a,) { print a; } print 2; }"
+		program, err := parse("test.lox", source)
+		if diagnostics := diagnosticsOf(t, err); len(diagnostics) != 1 {
+			t.Errorf("%q: got %d errors, want 1:\n%v", source, len(diagnostics), err)
+		}
+		if len(program) != 1 {
+			t.Fatalf("%q: got %d declarations, want 1", source, len(program))
+		}
+		outer, ok := program[0].(*ast.FunDeclaration)
+		if !ok {
+			t.Fatalf("%q: got %T, want *ast.FunDeclaration", source, program[0])
+		}
+		if len(outer.Body.Declarations) != 1 || textOf(source, outer.Body.Declarations[0].Location()) != "print 2;" {
+			t.Errorf("%q: got body %s, want only print 2;", source, textOf(source, outer.Body.Location()))
+		}
+	})
+}
+
+func TestErrorsAreReportedOnce(t *testing.T) {
+	sources := []string{
+		"print 1 }",
+		"print 1 } print 2 }",
+		"{ print 1 }",
+		"var x = 1 }",
+		"print (1 }",
+		"print ;",
+		"fun f(a,) {} }",
+	}
+	forEachBackend(t, func(t *testing.T, parse func(string, string) ([]ast.Declaration, error)) {
+		for _, source := range sources {
+			_, err := parse("test.lox", source)
+			reported := make(map[int]bool)
+			for _, d := range diagnosticsOf(t, err) {
+				span, _ := d.Span()
+				if reported[span.Start] {
+					t.Errorf("%q: more than one error at offset %d:\n%v", source, span.Start, err)
+				}
+				reported[span.Start] = true
+			}
+		}
+	})
+}
+
+// diagnosticsOf returns the diagnostics of err, failing the test if err is not a [diagnostic.List].
+func diagnosticsOf(t *testing.T, err error) diagnostic.List {
+	t.Helper()
+	diagnostics, ok := err.(diagnostic.List)
+	if !ok {
+		t.Fatalf("got error %v, want diagnostics", err)
+	}
+	return diagnostics
+}
+
+// TestBackendsAgree compares the syntax trees dumped from both backends, including the partial ones of programs with
+// syntax errors.
+func TestBackendsAgree(t *testing.T) {
+	sources := []string{
+		// Valid programs.
+		"",
+		"print 1 + 2 * 3 - -4 / !5;",
+		"var a = \"str\\n\\u{1F600}\"; a = b.c = d; print a == nil or !true and a <= 1;",
+		"fun f(a, b, c) { return a(b)(c).d.e(); } print f;",
+		"class A < B { init(x) { this.x = x; super.init(); } m() { return (this).x; } }",
+		"for (var i = 0; i < 10; i = i + 1) { if (i > 5) print i; else { while (false) {} } }",
+		"for (;;) {} for (x = 0; ; ) print x;",
+		"// comment\n/* block /* nested */ */ print (((1)));",
+		largeProgram(2),
+		// Syntax errors and recovery.
+		"class A { m() } class B {}",
+		"class A { m() n() {} } class B { o() {} }",
+		"class A { m(a,) {} n() {} }",
+		"class A { m() { print; } n() {} }",
+		"class A { 1 } class B {}",
+		"class A { m(a b) {} n() {} }",
+		"class A { m { } n() {} }",
+		"class A { m() {} print 1; }",
+		"class A < { m() {} }",
+		"class { m() {} } var x;",
+		"class A { m() {}",
+		"class A { { } m() {} }",
+		"class A { m() { { } n() {} }",
+		"class A < B { init() { super.init( } m() {} }",
+		"fun f( {} var x;",
+		"fun f(a,) {} var x;",
+		"fun f() var x;",
+		"fun () {} var x;",
+		"fun f() { fun g( } print 2; }",
+		"fun outer() { fun f(a,) { print a; } print 2; }",
+		"fun f(a b) { print a; } print 2;",
+		"var = 1; var y;",
+		"var x = ; var y;",
+		"var x = 1 var y;",
+		"print 1 print 2;",
+		"print (1; print 2;",
+		"if (x print 1; print 2;",
+		"if (x) print 1; else print; print 2;",
+		"while (x { print 1; } print 2;",
+		"for (var i = 0; i < 1 i = i + 1) print i; print 2;",
+		"for (var = 1;;) print 1; print 2;",
+		"for (;;) print; print 2;",
+		"{ var x = 1; print x print 3; } print 2;",
+		"f(1, ; print 2;",
+		"f(1 2); print 3;",
+		"a.; super; super.; print 2;",
+		"print 1; } print 2;",
+		"print 1;; print 2;",
+		"{ { var x; } ; } ;",
+		"\"abc\\q\"; print 2;",
+		"\"unterminated",
+		"/* unterminated",
+		"print " + strings.Repeat("9", 400) + "; print 2;",
+		"a + b = c; (a) = 1; super.x = 1; print 2;",
+		"print @; print 1 @ 2; print 2;",
+		"print \"\xff\"; print 2;",
+		"return return; print 2;",
+	}
+	for _, source := range sources {
+		var dumps [2]string
+		var failed [2]bool
+		for i, backend := range backends {
+			program, err := backend.parse("test.lox", source)
+			builder := new(strings.Builder)
+			if err := ast.Dump(builder, program); err != nil {
+				t.Fatal(err)
+			}
+			dumps[i], failed[i] = builder.String(), err != nil
+		}
+		if failed[0] != failed[1] {
+			t.Errorf("%q: %s fails: %v, %s fails: %v", source, backends[0].name, failed[0], backends[1].name, failed[1])
+		}
+		if dumps[0] != dumps[1] {
+			t.Errorf("%q: syntax trees differ\n%s:\n%s\n%s:\n%s", source, backends[0].name, dumps[0], backends[1].name, dumps[1])
+		}
+	}
+}
+
+// largeProgram generates a valid program of n classes and functions, using every kind of declaration and statement.
+func largeProgram(n int) string {
+	builder := new(strings.Builder)
+	for i := range n {
+		fmt.Fprintf(builder, `// Declarations of round %[1]d.
+class Base%[1]d {
+  init(x, y) {
+    this.x = x;
+    this.y = y;
+  }
+  sum() { return this.x + this.y * %[1]d; }
+}
+
+class Derived%[1]d < Base%[1]d {
+  init(x) { super.init(x, "str\n%[1]d"); }
+  sum() { return super.sum() - -1 / 2; }
+}
+
+fun run%[1]d(n) {
+  var total = 0;
+  for (var i = 0; i < n; i = i + 1) {
+    if (i >= 10 and !(i == 20) or false) total = total + Derived%[1]d(i).sum();
+    else while (total != nil) { total = nil; }
+  }
+  /* the result */
+  return total;
+}
+print run%[1]d(%[1]d);
+`, i)
+	}
+	return builder.String()
+}
+
+func benchmarkParse(b *testing.B, parse func(filename, source string) ([]ast.Declaration, error)) {
+	source := largeProgram(100)
+	b.SetBytes(int64(len(source)))
+	for range b.N {
+		if _, err := parse("bench.lox", source); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPEG(b *testing.B)   { benchmarkParse(b, peg.ParseWithDiagnostic) }
+func BenchmarkPratt(b *testing.B) { benchmarkParse(b, pratt.Parse) }
+
+// textOf returns the source code covered by span, or a description of span if it is out of range.
+func textOf(source string, span ast.Span) string {
+	if span.Start < 0 || span.Start > span.End || span.End > len(source) {
+		return fmt.Sprintf("<invalid span %d..%d>", span.Start, span.End)
+	}
+	return source[span.Start:span.End]
+}
+
+// superCallOf returns the invocation of a method of super in the first statement of method, which is either an
+// expression statement or a return statement.
+func superCallOf(method *ast.FunDeclaration) (*ast.InvocationExpression, bool) {
+	if len(method.Body.Declarations) == 0 {
+		return nil, false
+	}
+	var expr ast.Expression
+	switch stmt := statementOf(method.Body.Declarations).(type) {
+	case *ast.ExpressionStatement:
+		expr = stmt.Expression
+	case *ast.ReturnStatement:
+		expr = stmt.Expression
+	}
+	call, ok := expr.(*ast.InvocationExpression)
+	if !ok {
+		return nil, false
+	}
+	access, ok := call.Callee.(*ast.PropertyAccessExpression)
+	if !ok {
+		return nil, false
+	}
+	_, isSuper := access.Target.(ast.Super)
+	return call, isSuper
+}
@@ -20,35 +20,192 @@ import (
 )
 
 func matchedTextOf(c *current) string {
-	return strings.TrimSpace(string(c.text))
+	text := string(c.text)
+	return strings.TrimSpace(text[skipTrivia(text):])
 }
 
 func (c *current) throw(message string) error {
 	return newLocatedError(c, message)
 }
 
+// throwUnterminated is only used by lexical rules matching a token that runs to the end of the source, after
+// which nothing else is reported.
+func (c *current) throwUnterminated(index int, message string) error {
+	return newUnterminatedError(c, index, message)
+}
+
+func (c *current) unexpected(expected string) error {
+	return newUnexpectedError(c, expected)
+}
+
 var g = &grammar{
 	rules: []*rule{
 		{
 			name:        "_",
 			displayName: "\"WHITESPACES\"",
-			pos:         position{line: 28, col: 1, offset: 489},
+			pos:         position{line: 40, col: 1, offset: 947},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 28, col: 19, offset: 507},
-				expr: &charClassMatcher{
-					pos:        position{line: 28, col: 19, offset: 507},
-					val:        "[ \\t\\r\\n]",
-					chars:      []rune{' ', '\t', '\r', '\n'},
-					ignoreCase: false,
-					inverted:   false,
+				pos: position{line: 40, col: 19, offset: 965},
+				expr: &choiceExpr{
+					pos: position{line: 40, col: 21, offset: 967},
+					alternatives: []any{
+						&oneOrMoreExpr{
+							pos: position{line: 40, col: 21, offset: 967},
+							expr: &charClassMatcher{
+								pos:        position{line: 40, col: 21, offset: 967},
+								val:        "[ \\t\\r\\n]",
+								chars:      []rune{' ', '\t', '\r', '\n'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 40, col: 34, offset: 980},
+							name: "LineComment",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 40, col: 48, offset: 994},
+							name: "BlockComment",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "LineComment",
+			pos:  position{line: 42, col: 1, offset: 1011},
+			expr: &seqExpr{
+				pos: position{line: 42, col: 15, offset: 1025},
+				exprs: []any{
+					&litMatcher{
+						pos:        position{line: 42, col: 15, offset: 1025},
+						val:        "//",
+						ignoreCase: false,
+						want:       "\"//\"",
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 42, col: 20, offset: 1030},
+						expr: &charClassMatcher{
+							pos:        position{line: 42, col: 20, offset: 1030},
+							val:        "[^\\n]",
+							chars:      []rune{'\n'},
+							ignoreCase: false,
+							inverted:   true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BlockComment",
+			pos:  position{line: 44, col: 1, offset: 1038},
+			expr: &choiceExpr{
+				pos: position{line: 44, col: 16, offset: 1053},
+				alternatives: []any{
+					&seqExpr{
+						pos: position{line: 44, col: 16, offset: 1053},
+						exprs: []any{
+							&litMatcher{
+								pos:        position{line: 44, col: 16, offset: 1053},
+								val:        "/*",
+								ignoreCase: false,
+								want:       "\"/*\"",
+							},
+							&zeroOrMoreExpr{
+								pos: position{line: 44, col: 21, offset: 1058},
+								expr: &choiceExpr{
+									pos: position{line: 44, col: 23, offset: 1060},
+									alternatives: []any{
+										&ruleRefExpr{
+											pos:  position{line: 44, col: 23, offset: 1060},
+											name: "BlockComment",
+										},
+										&seqExpr{
+											pos: position{line: 44, col: 38, offset: 1075},
+											exprs: []any{
+												&notExpr{
+													pos: position{line: 44, col: 38, offset: 1075},
+													expr: &litMatcher{
+														pos:        position{line: 44, col: 39, offset: 1076},
+														val:        "*/",
+														ignoreCase: false,
+														want:       "\"*/\"",
+													},
+												},
+												&anyMatcher{
+													line: 44, col: 44, offset: 1081,
+												},
+											},
+										},
+									},
+								},
+							},
+							&litMatcher{
+								pos:        position{line: 44, col: 49, offset: 1086},
+								val:        "*/",
+								ignoreCase: false,
+								want:       "\"*/\"",
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 44, col: 56, offset: 1093},
+						run: (*parser).callonBlockComment12,
+						expr: &seqExpr{
+							pos: position{line: 44, col: 56, offset: 1093},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 44, col: 56, offset: 1093},
+									val:        "/*",
+									ignoreCase: false,
+									want:       "\"/*\"",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 44, col: 61, offset: 1098},
+									expr: &choiceExpr{
+										pos: position{line: 44, col: 63, offset: 1100},
+										alternatives: []any{
+											&ruleRefExpr{
+												pos:  position{line: 44, col: 63, offset: 1100},
+												name: "BlockComment",
+											},
+											&seqExpr{
+												pos: position{line: 44, col: 78, offset: 1115},
+												exprs: []any{
+													&notExpr{
+														pos: position{line: 44, col: 78, offset: 1115},
+														expr: &litMatcher{
+															pos:        position{line: 44, col: 79, offset: 1116},
+															val:        "*/",
+															ignoreCase: false,
+															want:       "\"*/\"",
+														},
+													},
+													&anyMatcher{
+														line: 44, col: 84, offset: 1121,
+													},
+												},
+											},
+										},
+									},
+								},
+								&notExpr{
+									pos: position{line: 44, col: 89, offset: 1126},
+									expr: &anyMatcher{
+										line: 44, col: 90, offset: 1127,
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		{
 			name: "ALPHA",
-			pos:  position{line: 30, col: 1, offset: 521},
+			pos:  position{line: 48, col: 1, offset: 1200},
 			expr: &charClassMatcher{
-				pos:        position{line: 30, col: 9, offset: 529},
+				pos:        position{line: 48, col: 9, offset: 1208},
 				val:        "[a-zA-Z_]",
 				chars:      []rune{'_'},
 				ranges:     []rune{'a', 'z', 'A', 'Z'},
@@ -58,94 +215,314 @@ var g = &grammar{
 		},
 		{
 			name: "DIGIT",
-			pos:  position{line: 31, col: 1, offset: 540},
+			pos:  position{line: 49, col: 1, offset: 1218},
 			expr: &charClassMatcher{
-				pos:        position{line: 31, col: 9, offset: 548},
+				pos:        position{line: 49, col: 9, offset: 1226},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
 				inverted:   false,
 			},
 		},
+		{
+			name: "WORD_BOUNDARY",
+			pos:  position{line: 54, col: 1, offset: 1426},
+			expr: &notExpr{
+				pos: position{line: 54, col: 17, offset: 1442},
+				expr: &choiceExpr{
+					pos: position{line: 54, col: 20, offset: 1445},
+					alternatives: []any{
+						&ruleRefExpr{
+							pos:  position{line: 54, col: 20, offset: 1445},
+							name: "ALPHA",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 54, col: 28, offset: 1453},
+							name: "DIGIT",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RESERVED_WORD",
+			pos:  position{line: 56, col: 1, offset: 1462},
+			expr: &seqExpr{
+				pos: position{line: 56, col: 17, offset: 1478},
+				exprs: []any{
+					&choiceExpr{
+						pos: position{line: 57, col: 2, offset: 1481},
+						alternatives: []any{
+							&litMatcher{
+								pos:        position{line: 57, col: 2, offset: 1481},
+								val:        "and",
+								ignoreCase: false,
+								want:       "\"and\"",
+							},
+							&litMatcher{
+								pos:        position{line: 57, col: 10, offset: 1489},
+								val:        "class",
+								ignoreCase: false,
+								want:       "\"class\"",
+							},
+							&litMatcher{
+								pos:        position{line: 57, col: 20, offset: 1499},
+								val:        "else",
+								ignoreCase: false,
+								want:       "\"else\"",
+							},
+							&litMatcher{
+								pos:        position{line: 57, col: 29, offset: 1508},
+								val:        "false",
+								ignoreCase: false,
+								want:       "\"false\"",
+							},
+							&litMatcher{
+								pos:        position{line: 57, col: 39, offset: 1518},
+								val:        "for",
+								ignoreCase: false,
+								want:       "\"for\"",
+							},
+							&litMatcher{
+								pos:        position{line: 57, col: 47, offset: 1526},
+								val:        "fun",
+								ignoreCase: false,
+								want:       "\"fun\"",
+							},
+							&litMatcher{
+								pos:        position{line: 57, col: 55, offset: 1534},
+								val:        "if",
+								ignoreCase: false,
+								want:       "\"if\"",
+							},
+							&litMatcher{
+								pos:        position{line: 57, col: 62, offset: 1541},
+								val:        "nil",
+								ignoreCase: false,
+								want:       "\"nil\"",
+							},
+							&litMatcher{
+								pos:        position{line: 57, col: 70, offset: 1549},
+								val:        "or",
+								ignoreCase: false,
+								want:       "\"or\"",
+							},
+							&litMatcher{
+								pos:        position{line: 58, col: 2, offset: 1557},
+								val:        "print",
+								ignoreCase: false,
+								want:       "\"print\"",
+							},
+							&litMatcher{
+								pos:        position{line: 58, col: 12, offset: 1567},
+								val:        "return",
+								ignoreCase: false,
+								want:       "\"return\"",
+							},
+							&litMatcher{
+								pos:        position{line: 58, col: 23, offset: 1578},
+								val:        "super",
+								ignoreCase: false,
+								want:       "\"super\"",
+							},
+							&litMatcher{
+								pos:        position{line: 58, col: 33, offset: 1588},
+								val:        "this",
+								ignoreCase: false,
+								want:       "\"this\"",
+							},
+							&litMatcher{
+								pos:        position{line: 58, col: 42, offset: 1597},
+								val:        "true",
+								ignoreCase: false,
+								want:       "\"true\"",
+							},
+							&litMatcher{
+								pos:        position{line: 58, col: 51, offset: 1606},
+								val:        "var",
+								ignoreCase: false,
+								want:       "\"var\"",
+							},
+							&litMatcher{
+								pos:        position{line: 58, col: 59, offset: 1614},
+								val:        "while",
+								ignoreCase: false,
+								want:       "\"while\"",
+							},
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 59, col: 3, offset: 1624},
+						name: "WORD_BOUNDARY",
+					},
+				},
+			},
+		},
 		{
 			name: "IDENTIFIER",
-			pos:  position{line: 33, col: 1, offset: 557},
+			pos:  position{line: 61, col: 1, offset: 1639},
 			expr: &actionExpr{
-				pos: position{line: 33, col: 14, offset: 570},
+				pos: position{line: 61, col: 14, offset: 1652},
 				run: (*parser).callonIDENTIFIER1,
 				expr: &seqExpr{
-					pos: position{line: 33, col: 14, offset: 570},
+					pos: position{line: 61, col: 14, offset: 1652},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 33, col: 14, offset: 570},
+							pos:  position{line: 61, col: 14, offset: 1652},
 							name: "_",
 						},
+						&notExpr{
+							pos: position{line: 61, col: 16, offset: 1654},
+							expr: &ruleRefExpr{
+								pos:  position{line: 61, col: 17, offset: 1655},
+								name: "RESERVED_WORD",
+							},
+						},
 						&ruleRefExpr{
-							pos:  position{line: 33, col: 16, offset: 572},
+							pos:  position{line: 61, col: 31, offset: 1669},
 							name: "ALPHA",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 33, col: 22, offset: 578},
+							pos: position{line: 61, col: 37, offset: 1675},
 							expr: &choiceExpr{
-								pos: position{line: 33, col: 24, offset: 580},
+								pos: position{line: 61, col: 39, offset: 1677},
 								alternatives: []any{
 									&ruleRefExpr{
-										pos:  position{line: 33, col: 24, offset: 580},
+										pos:  position{line: 61, col: 39, offset: 1677},
 										name: "ALPHA",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 33, col: 32, offset: 588},
+										pos:  position{line: 61, col: 47, offset: 1685},
 										name: "DIGIT",
 									},
 								},
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 33, col: 41, offset: 597},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "STRING",
-			pos:  position{line: 38, col: 1, offset: 667},
-			expr: &actionExpr{
-				pos: position{line: 38, col: 10, offset: 676},
-				run: (*parser).callonSTRING1,
-				expr: &seqExpr{
-					pos: position{line: 38, col: 10, offset: 676},
-					exprs: []any{
-						&ruleRefExpr{
-							pos:  position{line: 38, col: 10, offset: 676},
-							name: "_",
-						},
-						&litMatcher{
-							pos:        position{line: 38, col: 12, offset: 678},
-							val:        "\"",
-							ignoreCase: false,
-							want:       "\"\\\"\"",
-						},
-						&zeroOrMoreExpr{
-							pos: position{line: 38, col: 16, offset: 682},
-							expr: &charClassMatcher{
-								pos:        position{line: 38, col: 16, offset: 682},
-								val:        "[^\"]",
-								chars:      []rune{'"'},
-								ignoreCase: false,
-								inverted:   true,
+			pos:  position{line: 68, col: 1, offset: 1858},
+			expr: &choiceExpr{
+				pos: position{line: 68, col: 10, offset: 1867},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 68, col: 10, offset: 1867},
+						run: (*parser).callonSTRING2,
+						expr: &seqExpr{
+							pos: position{line: 68, col: 10, offset: 1867},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 68, col: 10, offset: 1867},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 68, col: 12, offset: 1869},
+									val:        "\"",
+									ignoreCase: false,
+									want:       "\"\\\"\"",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 68, col: 16, offset: 1873},
+									expr: &choiceExpr{
+										pos: position{line: 68, col: 18, offset: 1875},
+										alternatives: []any{
+											&seqExpr{
+												pos: position{line: 68, col: 18, offset: 1875},
+												exprs: []any{
+													&litMatcher{
+														pos:        position{line: 68, col: 18, offset: 1875},
+														val:        "\\",
+														ignoreCase: false,
+														want:       "\"\\\\\"",
+													},
+													&anyMatcher{
+														line: 68, col: 23, offset: 1880,
+													},
+												},
+											},
+											&charClassMatcher{
+												pos:        position{line: 68, col: 27, offset: 1884},
+												val:        "[^\"\\\\]",
+												chars:      []rune{'"', '\\'},
+												ignoreCase: false,
+												inverted:   true,
+											},
+										},
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 68, col: 37, offset: 1894},
+									val:        "\"",
+									ignoreCase: false,
+									want:       "\"\\\"\"",
+								},
 							},
 						},
-						&litMatcher{
-							pos:        position{line: 38, col: 22, offset: 688},
-							val:        "\"",
-							ignoreCase: false,
-							want:       "\"\\\"\"",
-						},
-						&ruleRefExpr{
-							pos:  position{line: 38, col: 26, offset: 692},
-							name: "_",
+					},
+					&actionExpr{
+						pos: position{line: 70, col: 5, offset: 1929},
+						run: (*parser).callonSTRING13,
+						expr: &seqExpr{
+							pos: position{line: 70, col: 5, offset: 1929},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 70, col: 5, offset: 1929},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 70, col: 7, offset: 1931},
+									val:        "\"",
+									ignoreCase: false,
+									want:       "\"\\\"\"",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 70, col: 11, offset: 1935},
+									expr: &choiceExpr{
+										pos: position{line: 70, col: 13, offset: 1937},
+										alternatives: []any{
+											&seqExpr{
+												pos: position{line: 70, col: 13, offset: 1937},
+												exprs: []any{
+													&litMatcher{
+														pos:        position{line: 70, col: 13, offset: 1937},
+														val:        "\\",
+														ignoreCase: false,
+														want:       "\"\\\\\"",
+													},
+													&anyMatcher{
+														line: 70, col: 18, offset: 1942,
+													},
+												},
+											},
+											&charClassMatcher{
+												pos:        position{line: 70, col: 22, offset: 1946},
+												val:        "[^\"\\\\]",
+												chars:      []rune{'"', '\\'},
+												ignoreCase: false,
+												inverted:   true,
+											},
+										},
+									},
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 70, col: 32, offset: 1956},
+									expr: &litMatcher{
+										pos:        position{line: 70, col: 32, offset: 1956},
+										val:        "\\",
+										ignoreCase: false,
+										want:       "\"\\\\\"",
+									},
+								},
+								&notExpr{
+									pos: position{line: 70, col: 38, offset: 1962},
+									expr: &anyMatcher{
+										line: 70, col: 39, offset: 1963,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -153,399 +530,352 @@ var g = &grammar{
 		},
 		{
 			name: "NUMBER",
-			pos:  position{line: 43, col: 1, offset: 765},
+			pos:  position{line: 74, col: 1, offset: 2085},
 			expr: &actionExpr{
-				pos: position{line: 43, col: 10, offset: 774},
+				pos: position{line: 74, col: 10, offset: 2094},
 				run: (*parser).callonNUMBER1,
 				expr: &seqExpr{
-					pos: position{line: 43, col: 10, offset: 774},
+					pos: position{line: 74, col: 10, offset: 2094},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 43, col: 10, offset: 774},
+							pos:  position{line: 74, col: 10, offset: 2094},
 							name: "_",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 43, col: 12, offset: 776},
+							pos: position{line: 74, col: 12, offset: 2096},
 							expr: &ruleRefExpr{
-								pos:  position{line: 43, col: 12, offset: 776},
+								pos:  position{line: 74, col: 12, offset: 2096},
 								name: "DIGIT",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 43, col: 19, offset: 783},
+							pos: position{line: 74, col: 19, offset: 2103},
 							expr: &seqExpr{
-								pos: position{line: 43, col: 20, offset: 784},
+								pos: position{line: 74, col: 20, offset: 2104},
 								exprs: []any{
 									&litMatcher{
-										pos:        position{line: 43, col: 20, offset: 784},
+										pos:        position{line: 74, col: 20, offset: 2104},
 										val:        ".",
 										ignoreCase: false,
 										want:       "\".\"",
 									},
 									&oneOrMoreExpr{
-										pos: position{line: 43, col: 24, offset: 788},
+										pos: position{line: 74, col: 24, offset: 2108},
 										expr: &ruleRefExpr{
-											pos:  position{line: 43, col: 24, offset: 788},
+											pos:  position{line: 74, col: 24, offset: 2108},
 											name: "DIGIT",
 										},
 									},
 								},
 							},
 						},
-						&ruleRefExpr{
-							pos:  position{line: 43, col: 33, offset: 797},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "LEFT_PAREN",
-			pos:  position{line: 52, col: 1, offset: 949},
+			pos:  position{line: 78, col: 1, offset: 2147},
 			expr: &actionExpr{
-				pos: position{line: 52, col: 17, offset: 965},
+				pos: position{line: 78, col: 17, offset: 2163},
 				run: (*parser).callonLEFT_PAREN1,
 				expr: &seqExpr{
-					pos: position{line: 52, col: 17, offset: 965},
+					pos: position{line: 78, col: 17, offset: 2163},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 52, col: 17, offset: 965},
+							pos:  position{line: 78, col: 17, offset: 2163},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 52, col: 19, offset: 967},
+							pos:        position{line: 78, col: 19, offset: 2165},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 52, col: 23, offset: 971},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "RIGHT_PAREN",
-			pos:  position{line: 53, col: 1, offset: 1009},
+			pos:  position{line: 79, col: 1, offset: 2204},
 			expr: &actionExpr{
-				pos: position{line: 53, col: 17, offset: 1025},
+				pos: position{line: 79, col: 17, offset: 2220},
 				run: (*parser).callonRIGHT_PAREN1,
 				expr: &seqExpr{
-					pos: position{line: 53, col: 17, offset: 1025},
+					pos: position{line: 79, col: 17, offset: 2220},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 53, col: 17, offset: 1025},
+							pos:  position{line: 79, col: 17, offset: 2220},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 53, col: 19, offset: 1027},
+							pos:        position{line: 79, col: 19, offset: 2222},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 53, col: 23, offset: 1031},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "LEFT_BRACE",
-			pos:  position{line: 54, col: 1, offset: 1070},
+			pos:  position{line: 80, col: 1, offset: 2262},
 			expr: &actionExpr{
-				pos: position{line: 54, col: 17, offset: 1086},
+				pos: position{line: 80, col: 17, offset: 2278},
 				run: (*parser).callonLEFT_BRACE1,
 				expr: &seqExpr{
-					pos: position{line: 54, col: 17, offset: 1086},
+					pos: position{line: 80, col: 17, offset: 2278},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 54, col: 17, offset: 1086},
+							pos:  position{line: 80, col: 17, offset: 2278},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 54, col: 19, offset: 1088},
+							pos:        position{line: 80, col: 19, offset: 2280},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 54, col: 23, offset: 1092},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "RIGHT_BRACE",
-			pos:  position{line: 55, col: 1, offset: 1124},
+			pos:  position{line: 81, col: 1, offset: 2313},
 			expr: &actionExpr{
-				pos: position{line: 55, col: 17, offset: 1140},
+				pos: position{line: 81, col: 17, offset: 2329},
 				run: (*parser).callonRIGHT_BRACE1,
 				expr: &seqExpr{
-					pos: position{line: 55, col: 17, offset: 1140},
+					pos: position{line: 81, col: 17, offset: 2329},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 55, col: 17, offset: 1140},
+							pos:  position{line: 81, col: 17, offset: 2329},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 55, col: 19, offset: 1142},
+							pos:        position{line: 81, col: 19, offset: 2331},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 55, col: 23, offset: 1146},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "COMMA",
-			pos:  position{line: 56, col: 1, offset: 1179},
+			pos:  position{line: 82, col: 1, offset: 2365},
 			expr: &actionExpr{
-				pos: position{line: 56, col: 17, offset: 1195},
+				pos: position{line: 82, col: 17, offset: 2381},
 				run: (*parser).callonCOMMA1,
 				expr: &seqExpr{
-					pos: position{line: 56, col: 17, offset: 1195},
+					pos: position{line: 82, col: 17, offset: 2381},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 56, col: 17, offset: 1195},
+							pos:  position{line: 82, col: 17, offset: 2381},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 56, col: 19, offset: 1197},
+							pos:        position{line: 82, col: 19, offset: 2383},
 							val:        ",",
 							ignoreCase: false,
 							want:       "\",\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 56, col: 23, offset: 1201},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "DOT",
-			pos:  position{line: 57, col: 1, offset: 1229},
+			pos:  position{line: 83, col: 1, offset: 2412},
 			expr: &actionExpr{
-				pos: position{line: 57, col: 17, offset: 1245},
+				pos: position{line: 83, col: 17, offset: 2428},
 				run: (*parser).callonDOT1,
 				expr: &seqExpr{
-					pos: position{line: 57, col: 17, offset: 1245},
+					pos: position{line: 83, col: 17, offset: 2428},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 57, col: 17, offset: 1245},
+							pos:  position{line: 83, col: 17, offset: 2428},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 57, col: 19, offset: 1247},
+							pos:        position{line: 83, col: 19, offset: 2430},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 57, col: 23, offset: 1251},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "MINUS",
-			pos:  position{line: 58, col: 1, offset: 1277},
+			pos:  position{line: 84, col: 1, offset: 2457},
 			expr: &actionExpr{
-				pos: position{line: 58, col: 17, offset: 1293},
+				pos: position{line: 84, col: 17, offset: 2473},
 				run: (*parser).callonMINUS1,
 				expr: &seqExpr{
-					pos: position{line: 58, col: 17, offset: 1293},
+					pos: position{line: 84, col: 17, offset: 2473},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 17, offset: 1293},
+							pos:  position{line: 84, col: 17, offset: 2473},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 58, col: 19, offset: 1295},
+							pos:        position{line: 84, col: 19, offset: 2475},
 							val:        "-",
 							ignoreCase: false,
 							want:       "\"-\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 58, col: 23, offset: 1299},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "PLUS",
-			pos:  position{line: 59, col: 1, offset: 1327},
+			pos:  position{line: 85, col: 1, offset: 2504},
 			expr: &actionExpr{
-				pos: position{line: 59, col: 17, offset: 1343},
+				pos: position{line: 85, col: 17, offset: 2520},
 				run: (*parser).callonPLUS1,
 				expr: &seqExpr{
-					pos: position{line: 59, col: 17, offset: 1343},
+					pos: position{line: 85, col: 17, offset: 2520},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 59, col: 17, offset: 1343},
+							pos:  position{line: 85, col: 17, offset: 2520},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 59, col: 19, offset: 1345},
+							pos:        position{line: 85, col: 19, offset: 2522},
 							val:        "+",
 							ignoreCase: false,
 							want:       "\"+\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 59, col: 23, offset: 1349},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "SEMICOLON",
-			pos:  position{line: 60, col: 1, offset: 1376},
+			pos:  position{line: 86, col: 1, offset: 2550},
 			expr: &actionExpr{
-				pos: position{line: 60, col: 17, offset: 1392},
+				pos: position{line: 86, col: 17, offset: 2566},
 				run: (*parser).callonSEMICOLON1,
 				expr: &seqExpr{
-					pos: position{line: 60, col: 17, offset: 1392},
+					pos: position{line: 86, col: 17, offset: 2566},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 60, col: 17, offset: 1392},
+							pos:  position{line: 86, col: 17, offset: 2566},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 60, col: 19, offset: 1394},
+							pos:        position{line: 86, col: 19, offset: 2568},
 							val:        ";",
 							ignoreCase: false,
 							want:       "\";\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 60, col: 23, offset: 1398},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "SLASH",
-			pos:  position{line: 61, col: 1, offset: 1430},
+			pos:  position{line: 87, col: 1, offset: 2601},
 			expr: &actionExpr{
-				pos: position{line: 61, col: 17, offset: 1446},
+				pos: position{line: 87, col: 17, offset: 2617},
 				run: (*parser).callonSLASH1,
 				expr: &seqExpr{
-					pos: position{line: 61, col: 17, offset: 1446},
+					pos: position{line: 87, col: 17, offset: 2617},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 61, col: 17, offset: 1446},
+							pos:  position{line: 87, col: 17, offset: 2617},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 61, col: 19, offset: 1448},
+							pos:        position{line: 87, col: 19, offset: 2619},
 							val:        "/",
 							ignoreCase: false,
 							want:       "\"/\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 61, col: 23, offset: 1452},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "STAR",
-			pos:  position{line: 62, col: 1, offset: 1480},
+			pos:  position{line: 88, col: 1, offset: 2648},
 			expr: &actionExpr{
-				pos: position{line: 62, col: 17, offset: 1496},
+				pos: position{line: 88, col: 17, offset: 2664},
 				run: (*parser).callonSTAR1,
 				expr: &seqExpr{
-					pos: position{line: 62, col: 17, offset: 1496},
+					pos: position{line: 88, col: 17, offset: 2664},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 62, col: 17, offset: 1496},
+							pos:  position{line: 88, col: 17, offset: 2664},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 62, col: 19, offset: 1498},
+							pos:        position{line: 88, col: 19, offset: 2666},
 							val:        "*",
 							ignoreCase: false,
 							want:       "\"*\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 62, col: 23, offset: 1502},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "BANG",
-			pos:  position{line: 63, col: 1, offset: 1529},
+			pos:  position{line: 89, col: 1, offset: 2694},
 			expr: &actionExpr{
-				pos: position{line: 63, col: 17, offset: 1545},
+				pos: position{line: 89, col: 17, offset: 2710},
 				run: (*parser).callonBANG1,
 				expr: &seqExpr{
-					pos: position{line: 63, col: 17, offset: 1545},
+					pos: position{line: 89, col: 17, offset: 2710},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 63, col: 17, offset: 1545},
+							pos:  position{line: 89, col: 17, offset: 2710},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 63, col: 19, offset: 1547},
+							pos:        position{line: 89, col: 19, offset: 2712},
 							val:        "!",
 							ignoreCase: false,
 							want:       "\"!\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 63, col: 23, offset: 1551},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "EQUAL",
-			pos:  position{line: 64, col: 1, offset: 1578},
+			pos:  position{line: 90, col: 1, offset: 2740},
 			expr: &actionExpr{
-				pos: position{line: 64, col: 17, offset: 1594},
+				pos: position{line: 90, col: 17, offset: 2756},
 				run: (*parser).callonEQUAL1,
 				expr: &seqExpr{
-					pos: position{line: 64, col: 17, offset: 1594},
+					pos: position{line: 90, col: 17, offset: 2756},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 64, col: 17, offset: 1594},
+							pos:  position{line: 90, col: 17, offset: 2756},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 64, col: 19, offset: 1596},
+							pos:        position{line: 90, col: 19, offset: 2758},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 64, col: 23, offset: 1600},
-							name: "_",
+						&notExpr{
+							pos: position{line: 90, col: 23, offset: 2762},
+							expr: &litMatcher{
+								pos:        position{line: 90, col: 24, offset: 2763},
+								val:        "=",
+								ignoreCase: false,
+								want:       "\"=\"",
+							},
 						},
 					},
 				},
@@ -553,188 +883,164 @@ var g = &grammar{
 		},
 		{
 			name: "GREATER",
-			pos:  position{line: 65, col: 1, offset: 1628},
+			pos:  position{line: 91, col: 1, offset: 2792},
 			expr: &actionExpr{
-				pos: position{line: 65, col: 17, offset: 1644},
+				pos: position{line: 91, col: 17, offset: 2808},
 				run: (*parser).callonGREATER1,
 				expr: &seqExpr{
-					pos: position{line: 65, col: 17, offset: 1644},
+					pos: position{line: 91, col: 17, offset: 2808},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 65, col: 17, offset: 1644},
+							pos:  position{line: 91, col: 17, offset: 2808},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 65, col: 19, offset: 1646},
+							pos:        position{line: 91, col: 19, offset: 2810},
 							val:        ">",
 							ignoreCase: false,
 							want:       "\">\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 65, col: 23, offset: 1650},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "LESS",
-			pos:  position{line: 66, col: 1, offset: 1680},
+			pos:  position{line: 92, col: 1, offset: 2841},
 			expr: &actionExpr{
-				pos: position{line: 66, col: 17, offset: 1696},
+				pos: position{line: 92, col: 17, offset: 2857},
 				run: (*parser).callonLESS1,
 				expr: &seqExpr{
-					pos: position{line: 66, col: 17, offset: 1696},
+					pos: position{line: 92, col: 17, offset: 2857},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 66, col: 17, offset: 1696},
+							pos:  position{line: 92, col: 17, offset: 2857},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 66, col: 19, offset: 1698},
+							pos:        position{line: 92, col: 19, offset: 2859},
 							val:        "<",
 							ignoreCase: false,
 							want:       "\"<\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 66, col: 23, offset: 1702},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "BANG_EQUAL",
-			pos:  position{line: 68, col: 1, offset: 1731},
+			pos:  position{line: 94, col: 1, offset: 2888},
 			expr: &actionExpr{
-				pos: position{line: 68, col: 17, offset: 1747},
+				pos: position{line: 94, col: 17, offset: 2904},
 				run: (*parser).callonBANG_EQUAL1,
 				expr: &seqExpr{
-					pos: position{line: 68, col: 17, offset: 1747},
+					pos: position{line: 94, col: 17, offset: 2904},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 17, offset: 1747},
+							pos:  position{line: 94, col: 17, offset: 2904},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 68, col: 19, offset: 1749},
+							pos:        position{line: 94, col: 19, offset: 2906},
 							val:        "!=",
 							ignoreCase: false,
 							want:       "\"!=\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 68, col: 24, offset: 1754},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "EQUAL_EQUAL",
-			pos:  position{line: 69, col: 1, offset: 1786},
+			pos:  position{line: 95, col: 1, offset: 2940},
 			expr: &actionExpr{
-				pos: position{line: 69, col: 17, offset: 1802},
+				pos: position{line: 95, col: 17, offset: 2956},
 				run: (*parser).callonEQUAL_EQUAL1,
 				expr: &seqExpr{
-					pos: position{line: 69, col: 17, offset: 1802},
+					pos: position{line: 95, col: 17, offset: 2956},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 69, col: 17, offset: 1802},
+							pos:  position{line: 95, col: 17, offset: 2956},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 69, col: 19, offset: 1804},
+							pos:        position{line: 95, col: 19, offset: 2958},
 							val:        "==",
 							ignoreCase: false,
 							want:       "\"==\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 69, col: 24, offset: 1809},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "GREATER_EQUAL",
-			pos:  position{line: 70, col: 1, offset: 1842},
+			pos:  position{line: 96, col: 1, offset: 2993},
 			expr: &actionExpr{
-				pos: position{line: 70, col: 17, offset: 1858},
+				pos: position{line: 96, col: 17, offset: 3009},
 				run: (*parser).callonGREATER_EQUAL1,
 				expr: &seqExpr{
-					pos: position{line: 70, col: 17, offset: 1858},
+					pos: position{line: 96, col: 17, offset: 3009},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 70, col: 17, offset: 1858},
+							pos:  position{line: 96, col: 17, offset: 3009},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 70, col: 19, offset: 1860},
+							pos:        position{line: 96, col: 19, offset: 3011},
 							val:        ">=",
 							ignoreCase: false,
 							want:       "\">=\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 70, col: 24, offset: 1865},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "LESS_EQUAL",
-			pos:  position{line: 71, col: 1, offset: 1900},
+			pos:  position{line: 97, col: 1, offset: 3048},
 			expr: &actionExpr{
-				pos: position{line: 71, col: 17, offset: 1916},
+				pos: position{line: 97, col: 17, offset: 3064},
 				run: (*parser).callonLESS_EQUAL1,
 				expr: &seqExpr{
-					pos: position{line: 71, col: 17, offset: 1916},
+					pos: position{line: 97, col: 17, offset: 3064},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 71, col: 17, offset: 1916},
+							pos:  position{line: 97, col: 17, offset: 3064},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 71, col: 19, offset: 1918},
+							pos:        position{line: 97, col: 19, offset: 3066},
 							val:        "<=",
 							ignoreCase: false,
 							want:       "\"<=\"",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 71, col: 24, offset: 1923},
-							name: "_",
-						},
 					},
 				},
 			},
 		},
 		{
 			name: "AND",
-			pos:  position{line: 73, col: 1, offset: 1957},
+			pos:  position{line: 99, col: 1, offset: 3101},
 			expr: &actionExpr{
-				pos: position{line: 73, col: 17, offset: 1973},
+				pos: position{line: 99, col: 17, offset: 3117},
 				run: (*parser).callonAND1,
 				expr: &seqExpr{
-					pos: position{line: 73, col: 17, offset: 1973},
+					pos: position{line: 99, col: 17, offset: 3117},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 73, col: 17, offset: 1973},
+							pos:  position{line: 99, col: 17, offset: 3117},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 73, col: 19, offset: 1975},
+							pos:        position{line: 99, col: 19, offset: 3119},
 							val:        "and",
 							ignoreCase: false,
 							want:       "\"and\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 73, col: 28, offset: 1984},
-							name: "_",
+							pos:  position{line: 99, col: 25, offset: 3125},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -742,26 +1048,26 @@ var g = &grammar{
 		},
 		{
 			name: "CLASS",
-			pos:  position{line: 74, col: 1, offset: 2010},
+			pos:  position{line: 100, col: 1, offset: 3165},
 			expr: &actionExpr{
-				pos: position{line: 74, col: 17, offset: 2026},
+				pos: position{line: 100, col: 17, offset: 3181},
 				run: (*parser).callonCLASS1,
 				expr: &seqExpr{
-					pos: position{line: 74, col: 17, offset: 2026},
+					pos: position{line: 100, col: 17, offset: 3181},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 74, col: 17, offset: 2026},
+							pos:  position{line: 100, col: 17, offset: 3181},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 74, col: 19, offset: 2028},
+							pos:        position{line: 100, col: 19, offset: 3183},
 							val:        "class",
 							ignoreCase: false,
 							want:       "\"class\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 74, col: 28, offset: 2037},
-							name: "_",
+							pos:  position{line: 100, col: 27, offset: 3191},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -769,26 +1075,26 @@ var g = &grammar{
 		},
 		{
 			name: "ELSE",
-			pos:  position{line: 75, col: 1, offset: 2065},
+			pos:  position{line: 101, col: 1, offset: 3231},
 			expr: &actionExpr{
-				pos: position{line: 75, col: 17, offset: 2081},
+				pos: position{line: 101, col: 17, offset: 3247},
 				run: (*parser).callonELSE1,
 				expr: &seqExpr{
-					pos: position{line: 75, col: 17, offset: 2081},
+					pos: position{line: 101, col: 17, offset: 3247},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 75, col: 17, offset: 2081},
+							pos:  position{line: 101, col: 17, offset: 3247},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 75, col: 19, offset: 2083},
+							pos:        position{line: 101, col: 19, offset: 3249},
 							val:        "else",
 							ignoreCase: false,
 							want:       "\"else\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 75, col: 28, offset: 2092},
-							name: "_",
+							pos:  position{line: 101, col: 26, offset: 3256},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -796,26 +1102,26 @@ var g = &grammar{
 		},
 		{
 			name: "FALSE",
-			pos:  position{line: 76, col: 1, offset: 2119},
+			pos:  position{line: 102, col: 1, offset: 3296},
 			expr: &actionExpr{
-				pos: position{line: 76, col: 17, offset: 2135},
+				pos: position{line: 102, col: 17, offset: 3312},
 				run: (*parser).callonFALSE1,
 				expr: &seqExpr{
-					pos: position{line: 76, col: 17, offset: 2135},
+					pos: position{line: 102, col: 17, offset: 3312},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 76, col: 17, offset: 2135},
+							pos:  position{line: 102, col: 17, offset: 3312},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 76, col: 19, offset: 2137},
+							pos:        position{line: 102, col: 19, offset: 3314},
 							val:        "false",
 							ignoreCase: false,
 							want:       "\"false\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 76, col: 28, offset: 2146},
-							name: "_",
+							pos:  position{line: 102, col: 27, offset: 3322},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -823,26 +1129,26 @@ var g = &grammar{
 		},
 		{
 			name: "FOR",
-			pos:  position{line: 77, col: 1, offset: 2174},
+			pos:  position{line: 103, col: 1, offset: 3362},
 			expr: &actionExpr{
-				pos: position{line: 77, col: 17, offset: 2190},
+				pos: position{line: 103, col: 17, offset: 3378},
 				run: (*parser).callonFOR1,
 				expr: &seqExpr{
-					pos: position{line: 77, col: 17, offset: 2190},
+					pos: position{line: 103, col: 17, offset: 3378},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 77, col: 17, offset: 2190},
+							pos:  position{line: 103, col: 17, offset: 3378},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 77, col: 19, offset: 2192},
+							pos:        position{line: 103, col: 19, offset: 3380},
 							val:        "for",
 							ignoreCase: false,
 							want:       "\"for\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 77, col: 28, offset: 2201},
-							name: "_",
+							pos:  position{line: 103, col: 25, offset: 3386},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -850,26 +1156,26 @@ var g = &grammar{
 		},
 		{
 			name: "FUN",
-			pos:  position{line: 78, col: 1, offset: 2227},
+			pos:  position{line: 104, col: 1, offset: 3426},
 			expr: &actionExpr{
-				pos: position{line: 78, col: 17, offset: 2243},
+				pos: position{line: 104, col: 17, offset: 3442},
 				run: (*parser).callonFUN1,
 				expr: &seqExpr{
-					pos: position{line: 78, col: 17, offset: 2243},
+					pos: position{line: 104, col: 17, offset: 3442},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 78, col: 17, offset: 2243},
+							pos:  position{line: 104, col: 17, offset: 3442},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 78, col: 19, offset: 2245},
+							pos:        position{line: 104, col: 19, offset: 3444},
 							val:        "fun",
 							ignoreCase: false,
 							want:       "\"fun\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 78, col: 28, offset: 2254},
-							name: "_",
+							pos:  position{line: 104, col: 25, offset: 3450},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -877,26 +1183,26 @@ var g = &grammar{
 		},
 		{
 			name: "IF",
-			pos:  position{line: 79, col: 1, offset: 2280},
+			pos:  position{line: 105, col: 1, offset: 3490},
 			expr: &actionExpr{
-				pos: position{line: 79, col: 17, offset: 2296},
+				pos: position{line: 105, col: 17, offset: 3506},
 				run: (*parser).callonIF1,
 				expr: &seqExpr{
-					pos: position{line: 79, col: 17, offset: 2296},
+					pos: position{line: 105, col: 17, offset: 3506},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 79, col: 17, offset: 2296},
+							pos:  position{line: 105, col: 17, offset: 3506},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 79, col: 19, offset: 2298},
+							pos:        position{line: 105, col: 19, offset: 3508},
 							val:        "if",
 							ignoreCase: false,
 							want:       "\"if\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 79, col: 28, offset: 2307},
-							name: "_",
+							pos:  position{line: 105, col: 24, offset: 3513},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -904,26 +1210,26 @@ var g = &grammar{
 		},
 		{
 			name: "NIL",
-			pos:  position{line: 80, col: 1, offset: 2332},
+			pos:  position{line: 106, col: 1, offset: 3553},
 			expr: &actionExpr{
-				pos: position{line: 80, col: 17, offset: 2348},
+				pos: position{line: 106, col: 17, offset: 3569},
 				run: (*parser).callonNIL1,
 				expr: &seqExpr{
-					pos: position{line: 80, col: 17, offset: 2348},
+					pos: position{line: 106, col: 17, offset: 3569},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 80, col: 17, offset: 2348},
+							pos:  position{line: 106, col: 17, offset: 3569},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 80, col: 19, offset: 2350},
+							pos:        position{line: 106, col: 19, offset: 3571},
 							val:        "nil",
 							ignoreCase: false,
 							want:       "\"nil\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 80, col: 28, offset: 2359},
-							name: "_",
+							pos:  position{line: 106, col: 25, offset: 3577},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -931,26 +1237,26 @@ var g = &grammar{
 		},
 		{
 			name: "OR",
-			pos:  position{line: 81, col: 1, offset: 2385},
+			pos:  position{line: 107, col: 1, offset: 3617},
 			expr: &actionExpr{
-				pos: position{line: 81, col: 17, offset: 2401},
+				pos: position{line: 107, col: 17, offset: 3633},
 				run: (*parser).callonOR1,
 				expr: &seqExpr{
-					pos: position{line: 81, col: 17, offset: 2401},
+					pos: position{line: 107, col: 17, offset: 3633},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 81, col: 17, offset: 2401},
+							pos:  position{line: 107, col: 17, offset: 3633},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 81, col: 19, offset: 2403},
+							pos:        position{line: 107, col: 19, offset: 3635},
 							val:        "or",
 							ignoreCase: false,
 							want:       "\"or\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 81, col: 28, offset: 2412},
-							name: "_",
+							pos:  position{line: 107, col: 24, offset: 3640},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -958,26 +1264,26 @@ var g = &grammar{
 		},
 		{
 			name: "PRINT",
-			pos:  position{line: 82, col: 1, offset: 2437},
+			pos:  position{line: 108, col: 1, offset: 3680},
 			expr: &actionExpr{
-				pos: position{line: 82, col: 17, offset: 2453},
+				pos: position{line: 108, col: 17, offset: 3696},
 				run: (*parser).callonPRINT1,
 				expr: &seqExpr{
-					pos: position{line: 82, col: 17, offset: 2453},
+					pos: position{line: 108, col: 17, offset: 3696},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 82, col: 17, offset: 2453},
+							pos:  position{line: 108, col: 17, offset: 3696},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 82, col: 19, offset: 2455},
+							pos:        position{line: 108, col: 19, offset: 3698},
 							val:        "print",
 							ignoreCase: false,
 							want:       "\"print\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 82, col: 28, offset: 2464},
-							name: "_",
+							pos:  position{line: 108, col: 27, offset: 3706},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -985,26 +1291,26 @@ var g = &grammar{
 		},
 		{
 			name: "RETURN",
-			pos:  position{line: 83, col: 1, offset: 2492},
+			pos:  position{line: 109, col: 1, offset: 3746},
 			expr: &actionExpr{
-				pos: position{line: 83, col: 17, offset: 2508},
+				pos: position{line: 109, col: 17, offset: 3762},
 				run: (*parser).callonRETURN1,
 				expr: &seqExpr{
-					pos: position{line: 83, col: 17, offset: 2508},
+					pos: position{line: 109, col: 17, offset: 3762},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 83, col: 17, offset: 2508},
+							pos:  position{line: 109, col: 17, offset: 3762},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 83, col: 19, offset: 2510},
+							pos:        position{line: 109, col: 19, offset: 3764},
 							val:        "return",
 							ignoreCase: false,
 							want:       "\"return\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 83, col: 28, offset: 2519},
-							name: "_",
+							pos:  position{line: 109, col: 28, offset: 3773},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -1012,26 +1318,26 @@ var g = &grammar{
 		},
 		{
 			name: "SUPER",
-			pos:  position{line: 84, col: 1, offset: 2548},
+			pos:  position{line: 110, col: 1, offset: 3813},
 			expr: &actionExpr{
-				pos: position{line: 84, col: 17, offset: 2564},
+				pos: position{line: 110, col: 17, offset: 3829},
 				run: (*parser).callonSUPER1,
 				expr: &seqExpr{
-					pos: position{line: 84, col: 17, offset: 2564},
+					pos: position{line: 110, col: 17, offset: 3829},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 84, col: 17, offset: 2564},
+							pos:  position{line: 110, col: 17, offset: 3829},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 84, col: 19, offset: 2566},
+							pos:        position{line: 110, col: 19, offset: 3831},
 							val:        "super",
 							ignoreCase: false,
 							want:       "\"super\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 84, col: 28, offset: 2575},
-							name: "_",
+							pos:  position{line: 110, col: 27, offset: 3839},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -1039,26 +1345,26 @@ var g = &grammar{
 		},
 		{
 			name: "THIS",
-			pos:  position{line: 85, col: 1, offset: 2603},
+			pos:  position{line: 111, col: 1, offset: 3879},
 			expr: &actionExpr{
-				pos: position{line: 85, col: 17, offset: 2619},
+				pos: position{line: 111, col: 17, offset: 3895},
 				run: (*parser).callonTHIS1,
 				expr: &seqExpr{
-					pos: position{line: 85, col: 17, offset: 2619},
+					pos: position{line: 111, col: 17, offset: 3895},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 85, col: 17, offset: 2619},
+							pos:  position{line: 111, col: 17, offset: 3895},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 85, col: 19, offset: 2621},
+							pos:        position{line: 111, col: 19, offset: 3897},
 							val:        "this",
 							ignoreCase: false,
 							want:       "\"this\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 85, col: 28, offset: 2630},
-							name: "_",
+							pos:  position{line: 111, col: 26, offset: 3904},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -1066,26 +1372,26 @@ var g = &grammar{
 		},
 		{
 			name: "TRUE",
-			pos:  position{line: 86, col: 1, offset: 2657},
+			pos:  position{line: 112, col: 1, offset: 3944},
 			expr: &actionExpr{
-				pos: position{line: 86, col: 17, offset: 2673},
+				pos: position{line: 112, col: 17, offset: 3960},
 				run: (*parser).callonTRUE1,
 				expr: &seqExpr{
-					pos: position{line: 86, col: 17, offset: 2673},
+					pos: position{line: 112, col: 17, offset: 3960},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 86, col: 17, offset: 2673},
+							pos:  position{line: 112, col: 17, offset: 3960},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 86, col: 19, offset: 2675},
+							pos:        position{line: 112, col: 19, offset: 3962},
 							val:        "true",
 							ignoreCase: false,
 							want:       "\"true\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 86, col: 28, offset: 2684},
-							name: "_",
+							pos:  position{line: 112, col: 26, offset: 3969},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -1093,26 +1399,26 @@ var g = &grammar{
 		},
 		{
 			name: "VAR",
-			pos:  position{line: 87, col: 1, offset: 2711},
+			pos:  position{line: 113, col: 1, offset: 4009},
 			expr: &actionExpr{
-				pos: position{line: 87, col: 17, offset: 2727},
+				pos: position{line: 113, col: 17, offset: 4025},
 				run: (*parser).callonVAR1,
 				expr: &seqExpr{
-					pos: position{line: 87, col: 17, offset: 2727},
+					pos: position{line: 113, col: 17, offset: 4025},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 87, col: 17, offset: 2727},
+							pos:  position{line: 113, col: 17, offset: 4025},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 87, col: 19, offset: 2729},
+							pos:        position{line: 113, col: 19, offset: 4027},
 							val:        "var",
 							ignoreCase: false,
 							want:       "\"var\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 87, col: 28, offset: 2738},
-							name: "_",
+							pos:  position{line: 113, col: 25, offset: 4033},
+							name: "WORD_BOUNDARY",
 						},
 					},
 				},
@@ -1120,26 +1426,58 @@ var g = &grammar{
 		},
 		{
 			name: "WHILE",
-			pos:  position{line: 88, col: 1, offset: 2764},
+			pos:  position{line: 114, col: 1, offset: 4073},
 			expr: &actionExpr{
-				pos: position{line: 88, col: 17, offset: 2780},
+				pos: position{line: 114, col: 17, offset: 4089},
 				run: (*parser).callonWHILE1,
 				expr: &seqExpr{
-					pos: position{line: 88, col: 17, offset: 2780},
+					pos: position{line: 114, col: 17, offset: 4089},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 88, col: 17, offset: 2780},
+							pos:  position{line: 114, col: 17, offset: 4089},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 88, col: 19, offset: 2782},
+							pos:        position{line: 114, col: 19, offset: 4091},
 							val:        "while",
 							ignoreCase: false,
 							want:       "\"while\"",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 88, col: 28, offset: 2791},
-							name: "_",
+							pos:  position{line: 114, col: 27, offset: 4099},
+							name: "WORD_BOUNDARY",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invocation",
+			pos:  position{line: 119, col: 1, offset: 4159},
+			expr: &actionExpr{
+				pos: position{line: 119, col: 14, offset: 4172},
+				run: (*parser).calloninvocation1,
+				expr: &seqExpr{
+					pos: position{line: 119, col: 14, offset: 4172},
+					exprs: []any{
+						&ruleRefExpr{
+							pos:  position{line: 119, col: 14, offset: 4172},
+							name: "LEFT_PAREN",
+						},
+						&labeledExpr{
+							pos:   position{line: 119, col: 25, offset: 4183},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 119, col: 30, offset: 4188},
+								expr: &ruleRefExpr{
+									pos:  position{line: 119, col: 30, offset: 4188},
+									name: "arguments",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 119, col: 41, offset: 4199},
+							name: "RIGHT_PAREN",
 						},
 					},
 				},
@@ -1147,31 +1485,31 @@ var g = &grammar{
 		},
 		{
 			name: "arguments",
-			pos:  position{line: 93, col: 1, offset: 2843},
+			pos:  position{line: 127, col: 1, offset: 4335},
 			expr: &actionExpr{
-				pos: position{line: 93, col: 13, offset: 2855},
+				pos: position{line: 127, col: 13, offset: 4347},
 				run: (*parser).callonarguments1,
 				expr: &labeledExpr{
-					pos:   position{line: 93, col: 13, offset: 2855},
+					pos:   position{line: 127, col: 13, offset: 4347},
 					label: "pat",
 					expr: &seqExpr{
-						pos: position{line: 93, col: 18, offset: 2860},
+						pos: position{line: 127, col: 18, offset: 4352},
 						exprs: []any{
 							&ruleRefExpr{
-								pos:  position{line: 93, col: 18, offset: 2860},
+								pos:  position{line: 127, col: 18, offset: 4352},
 								name: "Expression",
 							},
 							&zeroOrMoreExpr{
-								pos: position{line: 93, col: 29, offset: 2871},
+								pos: position{line: 127, col: 29, offset: 4363},
 								expr: &seqExpr{
-									pos: position{line: 93, col: 30, offset: 2872},
+									pos: position{line: 127, col: 30, offset: 4364},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 30, offset: 2872},
+											pos:  position{line: 127, col: 30, offset: 4364},
 											name: "COMMA",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 93, col: 36, offset: 2878},
+											pos:  position{line: 127, col: 36, offset: 4370},
 											name: "Expression",
 										},
 									},
@@ -1184,31 +1522,31 @@ var g = &grammar{
 		},
 		{
 			name: "parameters",
-			pos:  position{line: 105, col: 1, offset: 3138},
+			pos:  position{line: 139, col: 1, offset: 4618},
 			expr: &actionExpr{
-				pos: position{line: 105, col: 14, offset: 3151},
+				pos: position{line: 139, col: 14, offset: 4631},
 				run: (*parser).callonparameters1,
 				expr: &labeledExpr{
-					pos:   position{line: 105, col: 14, offset: 3151},
+					pos:   position{line: 139, col: 14, offset: 4631},
 					label: "pat",
 					expr: &seqExpr{
-						pos: position{line: 105, col: 19, offset: 3156},
+						pos: position{line: 139, col: 19, offset: 4636},
 						exprs: []any{
 							&ruleRefExpr{
-								pos:  position{line: 105, col: 19, offset: 3156},
+								pos:  position{line: 139, col: 19, offset: 4636},
 								name: "IDENTIFIER",
 							},
 							&zeroOrMoreExpr{
-								pos: position{line: 105, col: 30, offset: 3167},
+								pos: position{line: 139, col: 30, offset: 4647},
 								expr: &seqExpr{
-									pos: position{line: 105, col: 31, offset: 3168},
+									pos: position{line: 139, col: 31, offset: 4648},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 31, offset: 3168},
+											pos:  position{line: 139, col: 31, offset: 4648},
 											name: "COMMA",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 105, col: 37, offset: 3174},
+											pos:  position{line: 139, col: 37, offset: 4654},
 											name: "IDENTIFIER",
 										},
 									},
@@ -1221,48 +1559,48 @@ var g = &grammar{
 		},
 		{
 			name: "function",
-			pos:  position{line: 117, col: 1, offset: 3446},
+			pos:  position{line: 151, col: 1, offset: 4914},
 			expr: &choiceExpr{
-				pos: position{line: 117, col: 12, offset: 3457},
+				pos: position{line: 151, col: 12, offset: 4925},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 117, col: 12, offset: 3457},
+						pos: position{line: 151, col: 12, offset: 4925},
 						run: (*parser).callonfunction2,
 						expr: &seqExpr{
-							pos: position{line: 117, col: 12, offset: 3457},
+							pos: position{line: 151, col: 12, offset: 4925},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 117, col: 12, offset: 3457},
+									pos:   position{line: 151, col: 12, offset: 4925},
 									label: "name",
 									expr: &ruleRefExpr{
-										pos:  position{line: 117, col: 17, offset: 3462},
+										pos:  position{line: 151, col: 17, offset: 4930},
 										name: "IDENTIFIER",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 117, col: 28, offset: 3473},
+									pos:  position{line: 151, col: 28, offset: 4941},
 									name: "LEFT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 117, col: 39, offset: 3484},
+									pos:   position{line: 151, col: 39, offset: 4952},
 									label: "params",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 117, col: 46, offset: 3491},
+										pos: position{line: 151, col: 46, offset: 4959},
 										expr: &ruleRefExpr{
-											pos:  position{line: 117, col: 46, offset: 3491},
+											pos:  position{line: 151, col: 46, offset: 4959},
 											name: "parameters",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 117, col: 58, offset: 3503},
+									pos:  position{line: 151, col: 58, offset: 4971},
 									name: "RIGHT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 117, col: 70, offset: 3515},
+									pos:   position{line: 151, col: 70, offset: 4983},
 									label: "body",
 									expr: &ruleRefExpr{
-										pos:  position{line: 117, col: 75, offset: 3520},
+										pos:  position{line: 151, col: 75, offset: 4988},
 										name: "Block",
 									},
 								},
@@ -1270,73 +1608,76 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 123, col: 5, offset: 3692},
+						pos: position{line: 164, col: 5, offset: 5290},
 						run: (*parser).callonfunction13,
 						expr: &seqExpr{
-							pos: position{line: 123, col: 5, offset: 3692},
+							pos: position{line: 164, col: 5, offset: 5290},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 123, col: 5, offset: 3692},
+									pos:  position{line: 164, col: 5, offset: 5290},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 123, col: 16, offset: 3703},
+									pos:  position{line: 164, col: 16, offset: 5301},
 									name: "LEFT_PAREN",
 								},
-								&ruleRefExpr{
-									pos:  position{line: 123, col: 27, offset: 3714},
-									name: "parameters",
+								&zeroOrOneExpr{
+									pos: position{line: 164, col: 27, offset: 5312},
+									expr: &ruleRefExpr{
+										pos:  position{line: 164, col: 27, offset: 5312},
+										name: "parameters",
+									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 123, col: 38, offset: 3725},
+									pos:  position{line: 164, col: 39, offset: 5324},
 									name: "RIGHT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 125, col: 5, offset: 3798},
-						run: (*parser).callonfunction19,
+						pos: position{line: 166, col: 5, offset: 5395},
+						run: (*parser).callonfunction20,
 						expr: &seqExpr{
-							pos: position{line: 125, col: 5, offset: 3798},
+							pos: position{line: 166, col: 5, offset: 5395},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 125, col: 5, offset: 3798},
+									pos:  position{line: 166, col: 5, offset: 5395},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 125, col: 16, offset: 3809},
+									pos:  position{line: 166, col: 16, offset: 5406},
 									name: "LEFT_PAREN",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 125, col: 27, offset: 3820},
+									pos:  position{line: 166, col: 27, offset: 5417},
 									name: "parameters",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 127, col: 5, offset: 3890},
-						run: (*parser).callonfunction24,
+						pos: position{line: 168, col: 5, offset: 5485},
+						run: (*parser).callonfunction25,
 						expr: &seqExpr{
-							pos: position{line: 127, col: 5, offset: 3890},
+							pos: position{line: 168, col: 5, offset: 5485},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 127, col: 5, offset: 3890},
+									pos:  position{line: 168, col: 5, offset: 5485},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 127, col: 16, offset: 3901},
+									pos:  position{line: 168, col: 16, offset: 5496},
 									name: "LEFT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 129, col: 5, offset: 3985},
-						run: (*parser).callonfunction28,
+						pos: position{line: 170, col: 5, offset: 5578},
+						run: (*parser).callonfunction29,
 						expr: &ruleRefExpr{
-							pos:  position{line: 129, col: 5, offset: 3985},
+							pos:  position{line: 170, col: 5, offset: 5578},
 							name: "IDENTIFIER",
 						},
 					},
@@ -1345,122 +1686,122 @@ var g = &grammar{
 		},
 		{
 			name: "Primary",
-			pos:  position{line: 136, col: 1, offset: 4082},
+			pos:  position{line: 177, col: 1, offset: 5668},
 			expr: &choiceExpr{
-				pos: position{line: 137, col: 4, offset: 4094},
+				pos: position{line: 178, col: 4, offset: 5679},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 137, col: 4, offset: 4094},
+						pos: position{line: 178, col: 4, offset: 5679},
 						run: (*parser).callonPrimary2,
 						expr: &ruleRefExpr{
-							pos:  position{line: 137, col: 4, offset: 4094},
+							pos:  position{line: 178, col: 4, offset: 5679},
 							name: "TRUE",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 138, col: 4, offset: 4152},
+						pos: position{line: 179, col: 4, offset: 5760},
 						run: (*parser).callonPrimary4,
 						expr: &ruleRefExpr{
-							pos:  position{line: 138, col: 4, offset: 4152},
+							pos:  position{line: 179, col: 4, offset: 5760},
 							name: "FALSE",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 139, col: 4, offset: 4211},
+						pos: position{line: 180, col: 4, offset: 5842},
 						run: (*parser).callonPrimary6,
 						expr: &ruleRefExpr{
-							pos:  position{line: 139, col: 4, offset: 4211},
+							pos:  position{line: 180, col: 4, offset: 5842},
 							name: "NIL",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 140, col: 4, offset: 4254},
+						pos: position{line: 181, col: 4, offset: 5899},
 						run: (*parser).callonPrimary8,
 						expr: &ruleRefExpr{
-							pos:  position{line: 140, col: 4, offset: 4254},
+							pos:  position{line: 181, col: 4, offset: 5899},
 							name: "THIS",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 141, col: 4, offset: 4298},
+						pos: position{line: 182, col: 4, offset: 5957},
 						run: (*parser).callonPrimary10,
 						expr: &labeledExpr{
-							pos:   position{line: 141, col: 4, offset: 4298},
+							pos:   position{line: 182, col: 4, offset: 5957},
 							label: "n",
 							expr: &ruleRefExpr{
-								pos:  position{line: 141, col: 6, offset: 4300},
+								pos:  position{line: 182, col: 6, offset: 5959},
 								name: "NUMBER",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 142, col: 4, offset: 4333},
+						pos: position{line: 183, col: 4, offset: 5991},
 						run: (*parser).callonPrimary13,
 						expr: &labeledExpr{
-							pos:   position{line: 142, col: 4, offset: 4333},
+							pos:   position{line: 183, col: 4, offset: 5991},
 							label: "s",
 							expr: &ruleRefExpr{
-								pos:  position{line: 142, col: 6, offset: 4335},
+								pos:  position{line: 183, col: 6, offset: 5993},
 								name: "STRING",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 143, col: 4, offset: 4368},
+						pos: position{line: 184, col: 4, offset: 6025},
 						run: (*parser).callonPrimary16,
 						expr: &labeledExpr{
-							pos:   position{line: 143, col: 4, offset: 4368},
+							pos:   position{line: 184, col: 4, offset: 6025},
 							label: "i",
 							expr: &ruleRefExpr{
-								pos:  position{line: 143, col: 6, offset: 4370},
+								pos:  position{line: 184, col: 6, offset: 6027},
 								name: "IDENTIFIER",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 144, col: 4, offset: 4403},
+						pos: position{line: 188, col: 4, offset: 6097},
 						run: (*parser).callonPrimary19,
 						expr: &seqExpr{
-							pos: position{line: 144, col: 4, offset: 4403},
+							pos: position{line: 188, col: 4, offset: 6097},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 144, col: 4, offset: 4403},
+									pos:  position{line: 188, col: 4, offset: 6097},
 									name: "LEFT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 144, col: 15, offset: 4414},
+									pos:   position{line: 188, col: 15, offset: 6108},
 									label: "e",
 									expr: &ruleRefExpr{
-										pos:  position{line: 144, col: 17, offset: 4416},
+										pos:  position{line: 188, col: 17, offset: 6110},
 										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 144, col: 28, offset: 4427},
+									pos:  position{line: 188, col: 28, offset: 6121},
 									name: "RIGHT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 147, col: 4, offset: 4466},
+						pos: position{line: 191, col: 4, offset: 6157},
 						run: (*parser).callonPrimary25,
 						expr: &seqExpr{
-							pos: position{line: 147, col: 4, offset: 4466},
+							pos: position{line: 191, col: 4, offset: 6157},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 147, col: 4, offset: 4466},
+									pos:  position{line: 191, col: 4, offset: 6157},
 									name: "SUPER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 147, col: 10, offset: 4472},
+									pos:  position{line: 191, col: 10, offset: 6163},
 									name: "DOT",
 								},
 								&labeledExpr{
-									pos:   position{line: 147, col: 14, offset: 4476},
+									pos:   position{line: 191, col: 14, offset: 6167},
 									label: "i",
 									expr: &ruleRefExpr{
-										pos:  position{line: 147, col: 16, offset: 4478},
+										pos:  position{line: 191, col: 16, offset: 6169},
 										name: "IDENTIFIER",
 									},
 								},
@@ -1472,58 +1813,42 @@ var g = &grammar{
 		},
 		{
 			name: "Call",
-			pos:  position{line: 154, col: 1, offset: 4610},
+			pos:  position{line: 202, col: 1, offset: 6464},
 			expr: &actionExpr{
-				pos: position{line: 154, col: 8, offset: 4617},
+				pos: position{line: 202, col: 8, offset: 6471},
 				run: (*parser).callonCall1,
 				expr: &seqExpr{
-					pos: position{line: 154, col: 8, offset: 4617},
+					pos: position{line: 202, col: 8, offset: 6471},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 154, col: 8, offset: 4617},
+							pos:   position{line: 202, col: 8, offset: 6471},
 							label: "e",
 							expr: &ruleRefExpr{
-								pos:  position{line: 154, col: 10, offset: 4619},
+								pos:  position{line: 202, col: 10, offset: 6473},
 								name: "Primary",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 154, col: 18, offset: 4627},
+							pos:   position{line: 202, col: 18, offset: 6481},
 							label: "pat",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 154, col: 22, offset: 4631},
+								pos: position{line: 202, col: 22, offset: 6485},
 								expr: &choiceExpr{
-									pos: position{line: 154, col: 23, offset: 4632},
+									pos: position{line: 202, col: 23, offset: 6486},
 									alternatives: []any{
-										&seqExpr{
-											pos: position{line: 154, col: 23, offset: 4632},
-											exprs: []any{
-												&ruleRefExpr{
-													pos:  position{line: 154, col: 23, offset: 4632},
-													name: "LEFT_PAREN",
-												},
-												&zeroOrOneExpr{
-													pos: position{line: 154, col: 34, offset: 4643},
-													expr: &ruleRefExpr{
-														pos:  position{line: 154, col: 34, offset: 4643},
-														name: "arguments",
-													},
-												},
-												&ruleRefExpr{
-													pos:  position{line: 154, col: 45, offset: 4654},
-													name: "RIGHT_PAREN",
-												},
-											},
+										&ruleRefExpr{
+											pos:  position{line: 202, col: 23, offset: 6486},
+											name: "invocation",
 										},
 										&seqExpr{
-											pos: position{line: 154, col: 59, offset: 4668},
+											pos: position{line: 202, col: 36, offset: 6499},
 											exprs: []any{
 												&ruleRefExpr{
-													pos:  position{line: 154, col: 59, offset: 4668},
+													pos:  position{line: 202, col: 36, offset: 6499},
 													name: "DOT",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 154, col: 63, offset: 4672},
+													pos:  position{line: 202, col: 40, offset: 6503},
 													name: "IDENTIFIER",
 												},
 											},
@@ -1538,38 +1863,38 @@ var g = &grammar{
 		},
 		{
 			name: "Unary",
-			pos:  position{line: 176, col: 1, offset: 5179},
+			pos:  position{line: 227, col: 1, offset: 7105},
 			expr: &choiceExpr{
-				pos: position{line: 176, col: 9, offset: 5187},
+				pos: position{line: 227, col: 9, offset: 7113},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 176, col: 9, offset: 5187},
+						pos: position{line: 227, col: 9, offset: 7113},
 						run: (*parser).callonUnary2,
 						expr: &seqExpr{
-							pos: position{line: 176, col: 9, offset: 5187},
+							pos: position{line: 227, col: 9, offset: 7113},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 176, col: 9, offset: 5187},
+									pos:   position{line: 227, col: 9, offset: 7113},
 									label: "op",
 									expr: &choiceExpr{
-										pos: position{line: 176, col: 13, offset: 5191},
+										pos: position{line: 227, col: 13, offset: 7117},
 										alternatives: []any{
 											&ruleRefExpr{
-												pos:  position{line: 176, col: 13, offset: 5191},
+												pos:  position{line: 227, col: 13, offset: 7117},
 												name: "BANG",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 176, col: 20, offset: 5198},
+												pos:  position{line: 227, col: 20, offset: 7124},
 												name: "MINUS",
 											},
 										},
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 176, col: 27, offset: 5205},
+									pos:   position{line: 227, col: 27, offset: 7131},
 									label: "u",
 									expr: &ruleRefExpr{
-										pos:  position{line: 176, col: 29, offset: 5207},
+										pos:  position{line: 227, col: 29, offset: 7133},
 										name: "Unary",
 									},
 								},
@@ -1577,7 +1902,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 190, col: 5, offset: 5535},
+						pos:  position{line: 242, col: 5, offset: 7470},
 						name: "Call",
 					},
 				},
@@ -1585,44 +1910,44 @@ var g = &grammar{
 		},
 		{
 			name: "Factor",
-			pos:  position{line: 192, col: 1, offset: 5543},
+			pos:  position{line: 244, col: 1, offset: 7476},
 			expr: &actionExpr{
-				pos: position{line: 192, col: 14, offset: 5556},
+				pos: position{line: 244, col: 14, offset: 7489},
 				run: (*parser).callonFactor1,
 				expr: &seqExpr{
-					pos: position{line: 192, col: 14, offset: 5556},
+					pos: position{line: 244, col: 14, offset: 7489},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 192, col: 14, offset: 5556},
+							pos:   position{line: 244, col: 14, offset: 7489},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 192, col: 16, offset: 5558},
+								pos:  position{line: 244, col: 16, offset: 7491},
 								name: "Unary",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 192, col: 27, offset: 5569},
+							pos:   position{line: 244, col: 27, offset: 7502},
 							label: "pat",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 192, col: 31, offset: 5573},
+								pos: position{line: 244, col: 31, offset: 7506},
 								expr: &seqExpr{
-									pos: position{line: 192, col: 32, offset: 5574},
+									pos: position{line: 244, col: 32, offset: 7507},
 									exprs: []any{
 										&choiceExpr{
-											pos: position{line: 192, col: 33, offset: 5575},
+											pos: position{line: 244, col: 33, offset: 7508},
 											alternatives: []any{
 												&ruleRefExpr{
-													pos:  position{line: 192, col: 33, offset: 5575},
+													pos:  position{line: 244, col: 33, offset: 7508},
 													name: "SLASH",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 192, col: 41, offset: 5583},
+													pos:  position{line: 244, col: 41, offset: 7516},
 													name: "STAR",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 192, col: 47, offset: 5589},
+											pos:  position{line: 244, col: 47, offset: 7522},
 											name: "Unary",
 										},
 									},
@@ -1635,44 +1960,44 @@ var g = &grammar{
 		},
 		{
 			name: "Term",
-			pos:  position{line: 193, col: 1, offset: 5664},
+			pos:  position{line: 245, col: 1, offset: 7596},
 			expr: &actionExpr{
-				pos: position{line: 193, col: 14, offset: 5677},
+				pos: position{line: 245, col: 14, offset: 7609},
 				run: (*parser).callonTerm1,
 				expr: &seqExpr{
-					pos: position{line: 193, col: 14, offset: 5677},
+					pos: position{line: 245, col: 14, offset: 7609},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 193, col: 14, offset: 5677},
+							pos:   position{line: 245, col: 14, offset: 7609},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 193, col: 16, offset: 5679},
+								pos:  position{line: 245, col: 16, offset: 7611},
 								name: "Factor",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 193, col: 27, offset: 5690},
+							pos:   position{line: 245, col: 27, offset: 7622},
 							label: "pat",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 193, col: 31, offset: 5694},
+								pos: position{line: 245, col: 31, offset: 7626},
 								expr: &seqExpr{
-									pos: position{line: 193, col: 32, offset: 5695},
+									pos: position{line: 245, col: 32, offset: 7627},
 									exprs: []any{
 										&choiceExpr{
-											pos: position{line: 193, col: 33, offset: 5696},
+											pos: position{line: 245, col: 33, offset: 7628},
 											alternatives: []any{
 												&ruleRefExpr{
-													pos:  position{line: 193, col: 33, offset: 5696},
+													pos:  position{line: 245, col: 33, offset: 7628},
 													name: "MINUS",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 193, col: 41, offset: 5704},
+													pos:  position{line: 245, col: 41, offset: 7636},
 													name: "PLUS",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 193, col: 47, offset: 5710},
+											pos:  position{line: 245, col: 47, offset: 7642},
 											name: "Factor",
 										},
 									},
@@ -1685,52 +2010,52 @@ var g = &grammar{
 		},
 		{
 			name: "Comparison",
-			pos:  position{line: 194, col: 1, offset: 5785},
+			pos:  position{line: 246, col: 1, offset: 7716},
 			expr: &actionExpr{
-				pos: position{line: 194, col: 14, offset: 5798},
+				pos: position{line: 246, col: 14, offset: 7729},
 				run: (*parser).callonComparison1,
 				expr: &seqExpr{
-					pos: position{line: 194, col: 14, offset: 5798},
+					pos: position{line: 246, col: 14, offset: 7729},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 194, col: 14, offset: 5798},
+							pos:   position{line: 246, col: 14, offset: 7729},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 194, col: 16, offset: 5800},
+								pos:  position{line: 246, col: 16, offset: 7731},
 								name: "Term",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 194, col: 27, offset: 5811},
+							pos:   position{line: 246, col: 27, offset: 7742},
 							label: "pat",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 194, col: 31, offset: 5815},
+								pos: position{line: 246, col: 31, offset: 7746},
 								expr: &seqExpr{
-									pos: position{line: 194, col: 32, offset: 5816},
+									pos: position{line: 246, col: 32, offset: 7747},
 									exprs: []any{
 										&choiceExpr{
-											pos: position{line: 194, col: 33, offset: 5817},
+											pos: position{line: 246, col: 33, offset: 7748},
 											alternatives: []any{
 												&ruleRefExpr{
-													pos:  position{line: 194, col: 33, offset: 5817},
+													pos:  position{line: 246, col: 33, offset: 7748},
 													name: "GREATER_EQUAL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 194, col: 49, offset: 5833},
+													pos:  position{line: 246, col: 49, offset: 7764},
 													name: "LESS_EQUAL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 194, col: 62, offset: 5846},
+													pos:  position{line: 246, col: 62, offset: 7777},
 													name: "GREATER",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 194, col: 72, offset: 5856},
+													pos:  position{line: 246, col: 72, offset: 7787},
 													name: "LESS",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 194, col: 78, offset: 5862},
+											pos:  position{line: 246, col: 78, offset: 7793},
 											name: "Term",
 										},
 									},
@@ -1743,44 +2068,44 @@ var g = &grammar{
 		},
 		{
 			name: "Equality",
-			pos:  position{line: 195, col: 1, offset: 5906},
+			pos:  position{line: 247, col: 1, offset: 7836},
 			expr: &actionExpr{
-				pos: position{line: 195, col: 14, offset: 5919},
+				pos: position{line: 247, col: 14, offset: 7849},
 				run: (*parser).callonEquality1,
 				expr: &seqExpr{
-					pos: position{line: 195, col: 14, offset: 5919},
+					pos: position{line: 247, col: 14, offset: 7849},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 195, col: 14, offset: 5919},
+							pos:   position{line: 247, col: 14, offset: 7849},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 195, col: 16, offset: 5921},
+								pos:  position{line: 247, col: 16, offset: 7851},
 								name: "Comparison",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 195, col: 27, offset: 5932},
+							pos:   position{line: 247, col: 27, offset: 7862},
 							label: "pat",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 195, col: 31, offset: 5936},
+								pos: position{line: 247, col: 31, offset: 7866},
 								expr: &seqExpr{
-									pos: position{line: 195, col: 32, offset: 5937},
+									pos: position{line: 247, col: 32, offset: 7867},
 									exprs: []any{
 										&choiceExpr{
-											pos: position{line: 195, col: 33, offset: 5938},
+											pos: position{line: 247, col: 33, offset: 7868},
 											alternatives: []any{
 												&ruleRefExpr{
-													pos:  position{line: 195, col: 33, offset: 5938},
+													pos:  position{line: 247, col: 33, offset: 7868},
 													name: "BANG_EQUAL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 195, col: 46, offset: 5951},
+													pos:  position{line: 247, col: 46, offset: 7881},
 													name: "EQUAL_EQUAL",
 												},
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 195, col: 59, offset: 5964},
+											pos:  position{line: 247, col: 59, offset: 7894},
 											name: "Comparison",
 										},
 									},
@@ -1793,35 +2118,35 @@ var g = &grammar{
 		},
 		{
 			name: "LogicalAnd",
-			pos:  position{line: 196, col: 1, offset: 6027},
+			pos:  position{line: 248, col: 1, offset: 7956},
 			expr: &actionExpr{
-				pos: position{line: 196, col: 14, offset: 6040},
+				pos: position{line: 248, col: 14, offset: 7969},
 				run: (*parser).callonLogicalAnd1,
 				expr: &seqExpr{
-					pos: position{line: 196, col: 14, offset: 6040},
+					pos: position{line: 248, col: 14, offset: 7969},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 196, col: 14, offset: 6040},
+							pos:   position{line: 248, col: 14, offset: 7969},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 196, col: 16, offset: 6042},
+								pos:  position{line: 248, col: 16, offset: 7971},
 								name: "Equality",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 196, col: 27, offset: 6053},
+							pos:   position{line: 248, col: 27, offset: 7982},
 							label: "pat",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 196, col: 31, offset: 6057},
+								pos: position{line: 248, col: 31, offset: 7986},
 								expr: &seqExpr{
-									pos: position{line: 196, col: 32, offset: 6058},
+									pos: position{line: 248, col: 32, offset: 7987},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 196, col: 32, offset: 6058},
+											pos:  position{line: 248, col: 32, offset: 7987},
 											name: "AND",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 196, col: 36, offset: 6062},
+											pos:  position{line: 248, col: 36, offset: 7991},
 											name: "Equality",
 										},
 									},
@@ -1834,35 +2159,35 @@ var g = &grammar{
 		},
 		{
 			name: "LogicalOr",
-			pos:  position{line: 197, col: 1, offset: 6148},
+			pos:  position{line: 249, col: 1, offset: 8076},
 			expr: &actionExpr{
-				pos: position{line: 197, col: 14, offset: 6161},
+				pos: position{line: 249, col: 14, offset: 8089},
 				run: (*parser).callonLogicalOr1,
 				expr: &seqExpr{
-					pos: position{line: 197, col: 14, offset: 6161},
+					pos: position{line: 249, col: 14, offset: 8089},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 197, col: 14, offset: 6161},
+							pos:   position{line: 249, col: 14, offset: 8089},
 							label: "l",
 							expr: &ruleRefExpr{
-								pos:  position{line: 197, col: 16, offset: 6163},
+								pos:  position{line: 249, col: 16, offset: 8091},
 								name: "LogicalAnd",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 197, col: 27, offset: 6174},
+							pos:   position{line: 249, col: 27, offset: 8102},
 							label: "pat",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 197, col: 31, offset: 6178},
+								pos: position{line: 249, col: 31, offset: 8106},
 								expr: &seqExpr{
-									pos: position{line: 197, col: 32, offset: 6179},
+									pos: position{line: 249, col: 32, offset: 8107},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 197, col: 32, offset: 6179},
+											pos:  position{line: 249, col: 32, offset: 8107},
 											name: "OR",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 197, col: 35, offset: 6182},
+											pos:  position{line: 249, col: 35, offset: 8110},
 											name: "LogicalAnd",
 										},
 									},
@@ -1875,53 +2200,62 @@ var g = &grammar{
 		},
 		{
 			name: "Assignment",
-			pos:  position{line: 199, col: 1, offset: 6271},
+			pos:  position{line: 253, col: 1, offset: 8307},
 			expr: &choiceExpr{
-				pos: position{line: 199, col: 14, offset: 6284},
+				pos: position{line: 253, col: 14, offset: 8320},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 199, col: 14, offset: 6284},
+						pos: position{line: 253, col: 14, offset: 8320},
 						run: (*parser).callonAssignment2,
 						expr: &seqExpr{
-							pos: position{line: 199, col: 14, offset: 6284},
+							pos: position{line: 253, col: 14, offset: 8320},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 199, col: 14, offset: 6284},
-									label: "prev",
-									expr: &zeroOrOneExpr{
-										pos: position{line: 199, col: 19, offset: 6289},
-										expr: &seqExpr{
-											pos: position{line: 199, col: 20, offset: 6290},
-											exprs: []any{
-												&ruleRefExpr{
-													pos:  position{line: 199, col: 20, offset: 6290},
-													name: "Call",
-												},
-												&ruleRefExpr{
-													pos:  position{line: 199, col: 25, offset: 6295},
-													name: "DOT",
-												},
-											},
-										},
+									pos:   position{line: 253, col: 14, offset: 8320},
+									label: "target",
+									expr: &ruleRefExpr{
+										pos:  position{line: 253, col: 21, offset: 8327},
+										name: "Call",
 									},
 								},
+								&ruleRefExpr{
+									pos:  position{line: 253, col: 26, offset: 8332},
+									name: "EQUAL",
+								},
 								&labeledExpr{
-									pos:   position{line: 199, col: 31, offset: 6301},
-									label: "i",
+									pos:   position{line: 253, col: 32, offset: 8338},
+									label: "value",
 									expr: &ruleRefExpr{
-										pos:  position{line: 199, col: 33, offset: 6303},
-										name: "IDENTIFIER",
+										pos:  position{line: 253, col: 38, offset: 8344},
+										name: "Assignment",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 255, col: 5, offset: 8398},
+						run: (*parser).callonAssignment9,
+						expr: &seqExpr{
+							pos: position{line: 255, col: 5, offset: 8398},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 255, col: 5, offset: 8398},
+									label: "target",
+									expr: &ruleRefExpr{
+										pos:  position{line: 255, col: 12, offset: 8405},
+										name: "LogicalOr",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 199, col: 44, offset: 6314},
+									pos:  position{line: 255, col: 22, offset: 8415},
 									name: "EQUAL",
 								},
 								&labeledExpr{
-									pos:   position{line: 199, col: 50, offset: 6320},
-									label: "e",
+									pos:   position{line: 255, col: 28, offset: 8421},
+									label: "value",
 									expr: &ruleRefExpr{
-										pos:  position{line: 199, col: 52, offset: 6322},
+										pos:  position{line: 255, col: 34, offset: 8427},
 										name: "Assignment",
 									},
 								},
@@ -1929,7 +2263,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 214, col: 5, offset: 6675},
+						pos:  position{line: 257, col: 5, offset: 8481},
 						name: "LogicalOr",
 					},
 				},
@@ -1937,44 +2271,44 @@ var g = &grammar{
 		},
 		{
 			name: "Expression",
-			pos:  position{line: 216, col: 1, offset: 6688},
+			pos:  position{line: 259, col: 1, offset: 8492},
 			expr: &ruleRefExpr{
-				pos:  position{line: 216, col: 14, offset: 6701},
+				pos:  position{line: 259, col: 14, offset: 8505},
 				name: "Assignment",
 			},
 		},
 		{
 			name: "Statement",
-			pos:  position{line: 221, col: 1, offset: 6741},
+			pos:  position{line: 264, col: 1, offset: 8540},
 			expr: &choiceExpr{
-				pos: position{line: 222, col: 4, offset: 6755},
+				pos: position{line: 265, col: 4, offset: 8553},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 222, col: 4, offset: 6755},
+						pos:  position{line: 265, col: 4, offset: 8553},
 						name: "ForStatement",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 223, col: 4, offset: 6772},
+						pos:  position{line: 266, col: 4, offset: 8569},
 						name: "IfStatement",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 224, col: 4, offset: 6788},
+						pos:  position{line: 267, col: 4, offset: 8584},
 						name: "PrintStatement",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 225, col: 4, offset: 6807},
+						pos:  position{line: 268, col: 4, offset: 8602},
 						name: "ReturnStatement",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 226, col: 4, offset: 6827},
+						pos:  position{line: 269, col: 4, offset: 8621},
 						name: "WhileStatement",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 227, col: 4, offset: 6846},
+						pos:  position{line: 270, col: 4, offset: 8639},
 						name: "Block",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 228, col: 4, offset: 6856},
+						pos:  position{line: 271, col: 4, offset: 8648},
 						name: "ExpressionStatement",
 					},
 				},
@@ -1982,36 +2316,36 @@ var g = &grammar{
 		},
 		{
 			name: "ExpressionStatement",
-			pos:  position{line: 230, col: 1, offset: 6879},
+			pos:  position{line: 273, col: 1, offset: 8669},
 			expr: &choiceExpr{
-				pos: position{line: 230, col: 23, offset: 6901},
+				pos: position{line: 273, col: 23, offset: 8691},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 230, col: 23, offset: 6901},
+						pos: position{line: 273, col: 23, offset: 8691},
 						run: (*parser).callonExpressionStatement2,
 						expr: &seqExpr{
-							pos: position{line: 230, col: 23, offset: 6901},
+							pos: position{line: 273, col: 23, offset: 8691},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 230, col: 23, offset: 6901},
+									pos:   position{line: 273, col: 23, offset: 8691},
 									label: "e",
 									expr: &ruleRefExpr{
-										pos:  position{line: 230, col: 25, offset: 6903},
+										pos:  position{line: 273, col: 25, offset: 8693},
 										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 230, col: 36, offset: 6914},
+									pos:  position{line: 273, col: 36, offset: 8704},
 									name: "SEMICOLON",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 235, col: 5, offset: 7086},
+						pos: position{line: 278, col: 5, offset: 8888},
 						run: (*parser).callonExpressionStatement7,
 						expr: &ruleRefExpr{
-							pos:  position{line: 235, col: 5, offset: 7086},
+							pos:  position{line: 278, col: 5, offset: 8888},
 							name: "Expression",
 						},
 					},
@@ -2020,80 +2354,80 @@ var g = &grammar{
 		},
 		{
 			name: "ForStatement",
-			pos:  position{line: 239, col: 1, offset: 7149},
+			pos:  position{line: 282, col: 1, offset: 8947},
 			expr: &choiceExpr{
-				pos: position{line: 239, col: 16, offset: 7164},
+				pos: position{line: 282, col: 16, offset: 8962},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 239, col: 16, offset: 7164},
+						pos: position{line: 282, col: 16, offset: 8962},
 						run: (*parser).callonForStatement2,
 						expr: &seqExpr{
-							pos: position{line: 239, col: 16, offset: 7164},
+							pos: position{line: 282, col: 16, offset: 8962},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 239, col: 16, offset: 7164},
+									pos:  position{line: 282, col: 16, offset: 8962},
 									name: "FOR",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 239, col: 20, offset: 7168},
+									pos:  position{line: 282, col: 20, offset: 8966},
 									name: "LEFT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 240, col: 2, offset: 7182},
+									pos:   position{line: 283, col: 2, offset: 8979},
 									label: "init",
 									expr: &choiceExpr{
-										pos: position{line: 240, col: 8, offset: 7188},
+										pos: position{line: 283, col: 8, offset: 8985},
 										alternatives: []any{
 											&ruleRefExpr{
-												pos:  position{line: 240, col: 8, offset: 7188},
+												pos:  position{line: 283, col: 8, offset: 8985},
 												name: "VarDeclaration",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 240, col: 25, offset: 7205},
+												pos:  position{line: 283, col: 25, offset: 9002},
 												name: "ExpressionStatement",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 240, col: 47, offset: 7227},
+												pos:  position{line: 283, col: 47, offset: 9024},
 												name: "SEMICOLON",
 											},
 										},
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 241, col: 2, offset: 7241},
+									pos:   position{line: 284, col: 2, offset: 9037},
 									label: "cond",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 241, col: 7, offset: 7246},
+										pos: position{line: 284, col: 7, offset: 9042},
 										expr: &ruleRefExpr{
-											pos:  position{line: 241, col: 7, offset: 7246},
+											pos:  position{line: 284, col: 7, offset: 9042},
 											name: "Expression",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 241, col: 19, offset: 7258},
+									pos:  position{line: 284, col: 19, offset: 9054},
 									name: "SEMICOLON",
 								},
 								&labeledExpr{
-									pos:   position{line: 242, col: 2, offset: 7271},
+									pos:   position{line: 285, col: 2, offset: 9066},
 									label: "inc",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 242, col: 6, offset: 7275},
+										pos: position{line: 285, col: 6, offset: 9070},
 										expr: &ruleRefExpr{
-											pos:  position{line: 242, col: 6, offset: 7275},
+											pos:  position{line: 285, col: 6, offset: 9070},
 											name: "Expression",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 243, col: 1, offset: 7288},
+									pos:  position{line: 286, col: 1, offset: 9082},
 									name: "RIGHT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 243, col: 13, offset: 7300},
+									pos:   position{line: 286, col: 13, offset: 9094},
 									label: "b",
 									expr: &ruleRefExpr{
-										pos:  position{line: 243, col: 15, offset: 7302},
+										pos:  position{line: 286, col: 15, offset: 9096},
 										name: "Statement",
 									},
 								},
@@ -2101,107 +2435,107 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 261, col: 5, offset: 7703},
+						pos: position{line: 305, col: 5, offset: 9517},
 						run: (*parser).callonForStatement21,
 						expr: &seqExpr{
-							pos: position{line: 261, col: 5, offset: 7703},
+							pos: position{line: 305, col: 5, offset: 9517},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 261, col: 5, offset: 7703},
+									pos:  position{line: 305, col: 5, offset: 9517},
 									name: "FOR",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 261, col: 9, offset: 7707},
+									pos:  position{line: 305, col: 9, offset: 9521},
 									name: "LEFT_PAREN",
 								},
 								&choiceExpr{
-									pos: position{line: 261, col: 21, offset: 7719},
+									pos: position{line: 305, col: 21, offset: 9533},
 									alternatives: []any{
 										&ruleRefExpr{
-											pos:  position{line: 261, col: 21, offset: 7719},
+											pos:  position{line: 305, col: 21, offset: 9533},
 											name: "VarDeclaration",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 261, col: 38, offset: 7736},
+											pos:  position{line: 305, col: 38, offset: 9550},
 											name: "ExpressionStatement",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 261, col: 60, offset: 7758},
+											pos:  position{line: 305, col: 60, offset: 9572},
 											name: "SEMICOLON",
 										},
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 261, col: 71, offset: 7769},
+									pos: position{line: 305, col: 71, offset: 9583},
 									expr: &ruleRefExpr{
-										pos:  position{line: 261, col: 71, offset: 7769},
+										pos:  position{line: 305, col: 71, offset: 9583},
 										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 261, col: 83, offset: 7781},
+									pos:  position{line: 305, col: 83, offset: 9595},
 									name: "SEMICOLON",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 261, col: 93, offset: 7791},
+									pos: position{line: 305, col: 93, offset: 9605},
 									expr: &ruleRefExpr{
-										pos:  position{line: 261, col: 93, offset: 7791},
+										pos:  position{line: 305, col: 93, offset: 9605},
 										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 261, col: 105, offset: 7803},
+									pos:  position{line: 305, col: 105, offset: 9617},
 									name: "RIGHT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 263, col: 5, offset: 7866},
+						pos: position{line: 307, col: 5, offset: 9678},
 						run: (*parser).callonForStatement35,
 						expr: &seqExpr{
-							pos: position{line: 263, col: 5, offset: 7866},
+							pos: position{line: 307, col: 5, offset: 9678},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 263, col: 5, offset: 7866},
+									pos:  position{line: 307, col: 5, offset: 9678},
 									name: "FOR",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 263, col: 9, offset: 7870},
+									pos:  position{line: 307, col: 9, offset: 9682},
 									name: "LEFT_PAREN",
 								},
 								&choiceExpr{
-									pos: position{line: 263, col: 21, offset: 7882},
+									pos: position{line: 307, col: 21, offset: 9694},
 									alternatives: []any{
 										&ruleRefExpr{
-											pos:  position{line: 263, col: 21, offset: 7882},
+											pos:  position{line: 307, col: 21, offset: 9694},
 											name: "VarDeclaration",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 263, col: 38, offset: 7899},
+											pos:  position{line: 307, col: 38, offset: 9711},
 											name: "ExpressionStatement",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 263, col: 60, offset: 7921},
+											pos:  position{line: 307, col: 60, offset: 9733},
 											name: "SEMICOLON",
 										},
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 263, col: 71, offset: 7932},
+									pos: position{line: 307, col: 71, offset: 9744},
 									expr: &ruleRefExpr{
-										pos:  position{line: 263, col: 71, offset: 7932},
+										pos:  position{line: 307, col: 71, offset: 9744},
 										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 263, col: 83, offset: 7944},
+									pos:  position{line: 307, col: 83, offset: 9756},
 									name: "SEMICOLON",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 263, col: 93, offset: 7954},
+									pos: position{line: 307, col: 93, offset: 9766},
 									expr: &ruleRefExpr{
-										pos:  position{line: 263, col: 93, offset: 7954},
+										pos:  position{line: 307, col: 93, offset: 9766},
 										name: "Expression",
 									},
 								},
@@ -2209,40 +2543,40 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 265, col: 5, offset: 8025},
+						pos: position{line: 309, col: 5, offset: 9835},
 						run: (*parser).callonForStatement48,
 						expr: &seqExpr{
-							pos: position{line: 265, col: 5, offset: 8025},
+							pos: position{line: 309, col: 5, offset: 9835},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 265, col: 5, offset: 8025},
+									pos:  position{line: 309, col: 5, offset: 9835},
 									name: "FOR",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 265, col: 9, offset: 8029},
+									pos:  position{line: 309, col: 9, offset: 9839},
 									name: "LEFT_PAREN",
 								},
 								&choiceExpr{
-									pos: position{line: 265, col: 21, offset: 8041},
+									pos: position{line: 309, col: 21, offset: 9851},
 									alternatives: []any{
 										&ruleRefExpr{
-											pos:  position{line: 265, col: 21, offset: 8041},
+											pos:  position{line: 309, col: 21, offset: 9851},
 											name: "VarDeclaration",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 265, col: 38, offset: 8058},
+											pos:  position{line: 309, col: 38, offset: 9868},
 											name: "ExpressionStatement",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 265, col: 60, offset: 8080},
+											pos:  position{line: 309, col: 60, offset: 9890},
 											name: "SEMICOLON",
 										},
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 265, col: 71, offset: 8091},
+									pos: position{line: 309, col: 71, offset: 9901},
 									expr: &ruleRefExpr{
-										pos:  position{line: 265, col: 71, offset: 8091},
+										pos:  position{line: 309, col: 71, offset: 9901},
 										name: "Expression",
 									},
 								},
@@ -2250,27 +2584,27 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 267, col: 5, offset: 8154},
+						pos: position{line: 311, col: 5, offset: 9962},
 						run: (*parser).callonForStatement58,
 						expr: &seqExpr{
-							pos: position{line: 267, col: 5, offset: 8154},
+							pos: position{line: 311, col: 5, offset: 9962},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 267, col: 5, offset: 8154},
+									pos:  position{line: 311, col: 5, offset: 9962},
 									name: "FOR",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 267, col: 9, offset: 8158},
+									pos:  position{line: 311, col: 9, offset: 9966},
 									name: "LEFT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 269, col: 5, offset: 8251},
+						pos: position{line: 313, col: 5, offset: 10057},
 						run: (*parser).callonForStatement62,
 						expr: &ruleRefExpr{
-							pos:  position{line: 269, col: 5, offset: 8251},
+							pos:  position{line: 313, col: 5, offset: 10057},
 							name: "FOR",
 						},
 					},
@@ -2279,58 +2613,58 @@ var g = &grammar{
 		},
 		{
 			name: "IfStatement",
-			pos:  position{line: 273, col: 1, offset: 8314},
+			pos:  position{line: 317, col: 1, offset: 10116},
 			expr: &choiceExpr{
-				pos: position{line: 273, col: 15, offset: 8328},
+				pos: position{line: 317, col: 15, offset: 10130},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 273, col: 15, offset: 8328},
+						pos: position{line: 317, col: 15, offset: 10130},
 						run: (*parser).callonIfStatement2,
 						expr: &seqExpr{
-							pos: position{line: 273, col: 15, offset: 8328},
+							pos: position{line: 317, col: 15, offset: 10130},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 273, col: 15, offset: 8328},
+									pos:  position{line: 317, col: 15, offset: 10130},
 									name: "IF",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 273, col: 18, offset: 8331},
+									pos:  position{line: 317, col: 18, offset: 10133},
 									name: "LEFT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 273, col: 29, offset: 8342},
+									pos:   position{line: 317, col: 29, offset: 10144},
 									label: "cond",
 									expr: &ruleRefExpr{
-										pos:  position{line: 273, col: 34, offset: 8347},
+										pos:  position{line: 317, col: 34, offset: 10149},
 										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 273, col: 45, offset: 8358},
+									pos:  position{line: 317, col: 45, offset: 10160},
 									name: "RIGHT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 273, col: 57, offset: 8370},
+									pos:   position{line: 317, col: 57, offset: 10172},
 									label: "then",
 									expr: &ruleRefExpr{
-										pos:  position{line: 273, col: 62, offset: 8375},
+										pos:  position{line: 317, col: 62, offset: 10177},
 										name: "Statement",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 273, col: 72, offset: 8385},
+									pos:   position{line: 317, col: 72, offset: 10187},
 									label: "otherwise",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 273, col: 82, offset: 8395},
+										pos: position{line: 317, col: 82, offset: 10197},
 										expr: &seqExpr{
-											pos: position{line: 273, col: 83, offset: 8396},
+											pos: position{line: 317, col: 83, offset: 10198},
 											exprs: []any{
 												&ruleRefExpr{
-													pos:  position{line: 273, col: 83, offset: 8396},
+													pos:  position{line: 317, col: 83, offset: 10198},
 													name: "ELSE",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 273, col: 88, offset: 8401},
+													pos:  position{line: 317, col: 88, offset: 10203},
 													name: "Statement",
 												},
 											},
@@ -2341,106 +2675,106 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 282, col: 5, offset: 8632},
+						pos: position{line: 330, col: 5, offset: 10586},
 						run: (*parser).callonIfStatement16,
 						expr: &seqExpr{
-							pos: position{line: 282, col: 5, offset: 8632},
+							pos: position{line: 330, col: 5, offset: 10586},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 5, offset: 8632},
+									pos:  position{line: 330, col: 5, offset: 10586},
 									name: "IF",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 8, offset: 8635},
+									pos:  position{line: 330, col: 8, offset: 10589},
 									name: "LEFT_PAREN",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 19, offset: 8646},
+									pos:  position{line: 330, col: 19, offset: 10600},
 									name: "Expression",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 30, offset: 8657},
+									pos:  position{line: 330, col: 30, offset: 10611},
 									name: "RIGHT_PAREN",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 42, offset: 8669},
+									pos:  position{line: 330, col: 42, offset: 10623},
 									name: "Statement",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 282, col: 52, offset: 8679},
+									pos:  position{line: 330, col: 52, offset: 10633},
 									name: "ELSE",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 284, col: 5, offset: 8750},
+						pos: position{line: 332, col: 5, offset: 10702},
 						run: (*parser).callonIfStatement24,
 						expr: &seqExpr{
-							pos: position{line: 284, col: 5, offset: 8750},
+							pos: position{line: 332, col: 5, offset: 10702},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 284, col: 5, offset: 8750},
+									pos:  position{line: 332, col: 5, offset: 10702},
 									name: "IF",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 284, col: 8, offset: 8753},
+									pos:  position{line: 332, col: 8, offset: 10705},
 									name: "LEFT_PAREN",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 284, col: 19, offset: 8764},
+									pos:  position{line: 332, col: 19, offset: 10716},
 									name: "Expression",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 284, col: 30, offset: 8775},
+									pos:  position{line: 332, col: 30, offset: 10727},
 									name: "RIGHT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 286, col: 5, offset: 8838},
+						pos: position{line: 334, col: 5, offset: 10788},
 						run: (*parser).callonIfStatement30,
 						expr: &seqExpr{
-							pos: position{line: 286, col: 5, offset: 8838},
+							pos: position{line: 334, col: 5, offset: 10788},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 5, offset: 8838},
+									pos:  position{line: 334, col: 5, offset: 10788},
 									name: "IF",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 8, offset: 8841},
+									pos:  position{line: 334, col: 8, offset: 10791},
 									name: "LEFT_PAREN",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 286, col: 19, offset: 8852},
+									pos:  position{line: 334, col: 19, offset: 10802},
 									name: "Expression",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 288, col: 5, offset: 8922},
+						pos: position{line: 336, col: 5, offset: 10870},
 						run: (*parser).callonIfStatement35,
 						expr: &seqExpr{
-							pos: position{line: 288, col: 5, offset: 8922},
+							pos: position{line: 336, col: 5, offset: 10870},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 5, offset: 8922},
+									pos:  position{line: 336, col: 5, offset: 10870},
 									name: "IF",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 288, col: 8, offset: 8925},
+									pos:  position{line: 336, col: 8, offset: 10873},
 									name: "LEFT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 290, col: 5, offset: 8990},
+						pos: position{line: 338, col: 5, offset: 10936},
 						run: (*parser).callonIfStatement39,
 						expr: &ruleRefExpr{
-							pos:  position{line: 290, col: 5, offset: 8990},
+							pos:  position{line: 338, col: 5, offset: 10936},
 							name: "IF",
 						},
 					},
@@ -2449,57 +2783,57 @@ var g = &grammar{
 		},
 		{
 			name: "PrintStatement",
-			pos:  position{line: 294, col: 1, offset: 9052},
+			pos:  position{line: 342, col: 1, offset: 10994},
 			expr: &choiceExpr{
-				pos: position{line: 294, col: 18, offset: 9069},
+				pos: position{line: 342, col: 18, offset: 11011},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 294, col: 18, offset: 9069},
+						pos: position{line: 342, col: 18, offset: 11011},
 						run: (*parser).callonPrintStatement2,
 						expr: &seqExpr{
-							pos: position{line: 294, col: 18, offset: 9069},
+							pos: position{line: 342, col: 18, offset: 11011},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 18, offset: 9069},
+									pos:  position{line: 342, col: 18, offset: 11011},
 									name: "PRINT",
 								},
 								&labeledExpr{
-									pos:   position{line: 294, col: 24, offset: 9075},
+									pos:   position{line: 342, col: 24, offset: 11017},
 									label: "e",
 									expr: &ruleRefExpr{
-										pos:  position{line: 294, col: 26, offset: 9077},
+										pos:  position{line: 342, col: 26, offset: 11019},
 										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 294, col: 37, offset: 9088},
+									pos:  position{line: 342, col: 37, offset: 11030},
 									name: "SEMICOLON",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 298, col: 5, offset: 9179},
+						pos: position{line: 347, col: 5, offset: 11142},
 						run: (*parser).callonPrintStatement8,
 						expr: &seqExpr{
-							pos: position{line: 298, col: 5, offset: 9179},
+							pos: position{line: 347, col: 5, offset: 11142},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 5, offset: 9179},
+									pos:  position{line: 347, col: 5, offset: 11142},
 									name: "PRINT",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 298, col: 11, offset: 9185},
+									pos:  position{line: 347, col: 11, offset: 11148},
 									name: "Expression",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 300, col: 5, offset: 9247},
+						pos: position{line: 349, col: 5, offset: 11208},
 						run: (*parser).callonPrintStatement12,
 						expr: &ruleRefExpr{
-							pos:  position{line: 300, col: 5, offset: 9247},
+							pos:  position{line: 349, col: 5, offset: 11208},
 							name: "PRINT",
 						},
 					},
@@ -2508,52 +2842,52 @@ var g = &grammar{
 		},
 		{
 			name: "ReturnStatement",
-			pos:  position{line: 304, col: 1, offset: 9306},
+			pos:  position{line: 353, col: 1, offset: 11263},
 			expr: &choiceExpr{
-				pos: position{line: 304, col: 19, offset: 9324},
+				pos: position{line: 353, col: 19, offset: 11281},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 304, col: 19, offset: 9324},
+						pos: position{line: 353, col: 19, offset: 11281},
 						run: (*parser).callonReturnStatement2,
 						expr: &seqExpr{
-							pos: position{line: 304, col: 19, offset: 9324},
+							pos: position{line: 353, col: 19, offset: 11281},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 304, col: 19, offset: 9324},
+									pos:  position{line: 353, col: 19, offset: 11281},
 									name: "RETURN",
 								},
 								&labeledExpr{
-									pos:   position{line: 304, col: 26, offset: 9331},
+									pos:   position{line: 353, col: 26, offset: 11288},
 									label: "e",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 304, col: 28, offset: 9333},
+										pos: position{line: 353, col: 28, offset: 11290},
 										expr: &ruleRefExpr{
-											pos:  position{line: 304, col: 28, offset: 9333},
+											pos:  position{line: 353, col: 28, offset: 11290},
 											name: "Expression",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 304, col: 40, offset: 9345},
+									pos:  position{line: 353, col: 40, offset: 11302},
 									name: "SEMICOLON",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 310, col: 5, offset: 9476},
+						pos: position{line: 359, col: 5, offset: 11440},
 						run: (*parser).callonReturnStatement9,
 						expr: &seqExpr{
-							pos: position{line: 310, col: 5, offset: 9476},
+							pos: position{line: 359, col: 5, offset: 11440},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 310, col: 5, offset: 9476},
+									pos:  position{line: 359, col: 5, offset: 11440},
 									name: "RETURN",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 310, col: 12, offset: 9483},
+									pos: position{line: 359, col: 12, offset: 11447},
 									expr: &ruleRefExpr{
-										pos:  position{line: 310, col: 12, offset: 9483},
+										pos:  position{line: 359, col: 12, offset: 11447},
 										name: "Expression",
 									},
 								},
@@ -2565,41 +2899,41 @@ var g = &grammar{
 		},
 		{
 			name: "WhileStatement",
-			pos:  position{line: 314, col: 1, offset: 9547},
+			pos:  position{line: 363, col: 1, offset: 11507},
 			expr: &choiceExpr{
-				pos: position{line: 314, col: 18, offset: 9564},
+				pos: position{line: 363, col: 18, offset: 11524},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 314, col: 18, offset: 9564},
+						pos: position{line: 363, col: 18, offset: 11524},
 						run: (*parser).callonWhileStatement2,
 						expr: &seqExpr{
-							pos: position{line: 314, col: 18, offset: 9564},
+							pos: position{line: 363, col: 18, offset: 11524},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 314, col: 18, offset: 9564},
+									pos:  position{line: 363, col: 18, offset: 11524},
 									name: "WHILE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 314, col: 24, offset: 9570},
+									pos:  position{line: 363, col: 24, offset: 11530},
 									name: "LEFT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 314, col: 35, offset: 9581},
+									pos:   position{line: 363, col: 35, offset: 11541},
 									label: "cond",
 									expr: &ruleRefExpr{
-										pos:  position{line: 314, col: 40, offset: 9586},
+										pos:  position{line: 363, col: 40, offset: 11546},
 										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 314, col: 51, offset: 9597},
+									pos:  position{line: 363, col: 51, offset: 11557},
 									name: "RIGHT_PAREN",
 								},
 								&labeledExpr{
-									pos:   position{line: 314, col: 63, offset: 9609},
+									pos:   position{line: 363, col: 63, offset: 11569},
 									label: "b",
 									expr: &ruleRefExpr{
-										pos:  position{line: 314, col: 65, offset: 9611},
+										pos:  position{line: 363, col: 65, offset: 11571},
 										name: "Statement",
 									},
 								},
@@ -2607,73 +2941,73 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 319, col: 5, offset: 9737},
+						pos: position{line: 372, col: 5, offset: 11797},
 						run: (*parser).callonWhileStatement11,
 						expr: &seqExpr{
-							pos: position{line: 319, col: 5, offset: 9737},
+							pos: position{line: 372, col: 5, offset: 11797},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 319, col: 5, offset: 9737},
+									pos:  position{line: 372, col: 5, offset: 11797},
 									name: "WHILE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 319, col: 11, offset: 9743},
+									pos:  position{line: 372, col: 11, offset: 11803},
 									name: "LEFT_PAREN",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 319, col: 22, offset: 9754},
+									pos:  position{line: 372, col: 22, offset: 11814},
 									name: "Expression",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 319, col: 33, offset: 9765},
+									pos:  position{line: 372, col: 33, offset: 11825},
 									name: "RIGHT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 321, col: 5, offset: 9839},
+						pos: position{line: 374, col: 5, offset: 11897},
 						run: (*parser).callonWhileStatement17,
 						expr: &seqExpr{
-							pos: position{line: 321, col: 5, offset: 9839},
+							pos: position{line: 374, col: 5, offset: 11897},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 321, col: 5, offset: 9839},
+									pos:  position{line: 374, col: 5, offset: 11897},
 									name: "WHILE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 321, col: 11, offset: 9845},
+									pos:  position{line: 374, col: 11, offset: 11903},
 									name: "LEFT_PAREN",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 321, col: 22, offset: 9856},
+									pos:  position{line: 374, col: 22, offset: 11914},
 									name: "Expression",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 323, col: 5, offset: 9926},
+						pos: position{line: 376, col: 5, offset: 11982},
 						run: (*parser).callonWhileStatement22,
 						expr: &seqExpr{
-							pos: position{line: 323, col: 5, offset: 9926},
+							pos: position{line: 376, col: 5, offset: 11982},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 323, col: 5, offset: 9926},
+									pos:  position{line: 376, col: 5, offset: 11982},
 									name: "WHILE",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 323, col: 11, offset: 9932},
+									pos:  position{line: 376, col: 11, offset: 11988},
 									name: "LEFT_PAREN",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 325, col: 5, offset: 10000},
+						pos: position{line: 378, col: 5, offset: 12054},
 						run: (*parser).callonWhileStatement26,
 						expr: &ruleRefExpr{
-							pos:  position{line: 325, col: 5, offset: 10000},
+							pos:  position{line: 378, col: 5, offset: 12054},
 							name: "WHILE",
 						},
 					},
@@ -2682,53 +3016,71 @@ var g = &grammar{
 		},
 		{
 			name: "Block",
-			pos:  position{line: 329, col: 1, offset: 10065},
+			pos:  position{line: 382, col: 1, offset: 12115},
 			expr: &choiceExpr{
-				pos: position{line: 329, col: 9, offset: 10073},
+				pos: position{line: 382, col: 9, offset: 12123},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 329, col: 9, offset: 10073},
+						pos: position{line: 382, col: 9, offset: 12123},
 						run: (*parser).callonBlock2,
 						expr: &seqExpr{
-							pos: position{line: 329, col: 9, offset: 10073},
+							pos: position{line: 382, col: 9, offset: 12123},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 329, col: 9, offset: 10073},
+									pos:  position{line: 382, col: 9, offset: 12123},
 									name: "LEFT_BRACE",
 								},
 								&labeledExpr{
-									pos:   position{line: 329, col: 20, offset: 10084},
+									pos:   position{line: 382, col: 20, offset: 12134},
 									label: "d",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 329, col: 22, offset: 10086},
-										expr: &ruleRefExpr{
-											pos:  position{line: 329, col: 22, offset: 10086},
-											name: "Declaration",
+										pos: position{line: 382, col: 22, offset: 12136},
+										expr: &choiceExpr{
+											pos: position{line: 382, col: 24, offset: 12138},
+											alternatives: []any{
+												&ruleRefExpr{
+													pos:  position{line: 382, col: 24, offset: 12138},
+													name: "Declaration",
+												},
+												&ruleRefExpr{
+													pos:  position{line: 382, col: 38, offset: 12152},
+													name: "StraySemicolon",
+												},
+											},
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 329, col: 35, offset: 10099},
+									pos:  position{line: 382, col: 56, offset: 12170},
 									name: "RIGHT_BRACE",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 338, col: 5, offset: 10381},
-						run: (*parser).callonBlock9,
+						pos: position{line: 384, col: 5, offset: 12271},
+						run: (*parser).callonBlock11,
 						expr: &seqExpr{
-							pos: position{line: 338, col: 5, offset: 10381},
+							pos: position{line: 384, col: 5, offset: 12271},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 338, col: 5, offset: 10381},
+									pos:  position{line: 384, col: 5, offset: 12271},
 									name: "LEFT_BRACE",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 338, col: 16, offset: 10392},
-									expr: &ruleRefExpr{
-										pos:  position{line: 338, col: 16, offset: 10392},
-										name: "Declaration",
+									pos: position{line: 384, col: 16, offset: 12282},
+									expr: &choiceExpr{
+										pos: position{line: 384, col: 18, offset: 12284},
+										alternatives: []any{
+											&ruleRefExpr{
+												pos:  position{line: 384, col: 18, offset: 12284},
+												name: "Declaration",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 384, col: 32, offset: 12298},
+												name: "StraySemicolon",
+											},
+										},
 									},
 								},
 							},
@@ -2739,40 +3091,327 @@ var g = &grammar{
 		},
 		{
 			name: "Declaration",
-			pos:  position{line: 345, col: 1, offset: 10504},
+			pos:  position{line: 397, col: 1, offset: 12824},
 			expr: &choiceExpr{
-				pos: position{line: 346, col: 4, offset: 10520},
+				pos: position{line: 397, col: 15, offset: 12838},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 397, col: 15, offset: 12838},
+						run: (*parser).callonDeclaration2,
+						expr: &seqExpr{
+							pos: position{line: 397, col: 15, offset: 12838},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 397, col: 15, offset: 12838},
+									label: "d",
+									expr: &ruleRefExpr{
+										pos:  position{line: 397, col: 17, offset: 12840},
+										name: "DeclarationKind",
+									},
+								},
+								&andCodeExpr{
+									pos: position{line: 397, col: 33, offset: 12856},
+									run: (*parser).callonDeclaration6,
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 399, col: 5, offset: 12903},
+						run: (*parser).callonDeclaration7,
+						expr: &seqExpr{
+							pos: position{line: 399, col: 5, offset: 12903},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 399, col: 5, offset: 12903},
+									name: "FunDeclaration",
+								},
+								&zeroOrMoreExpr{
+									pos: position{line: 399, col: 20, offset: 12918},
+									expr: &seqExpr{
+										pos: position{line: 399, col: 22, offset: 12920},
+										exprs: []any{
+											&notExpr{
+												pos: position{line: 399, col: 22, offset: 12920},
+												expr: &ruleRefExpr{
+													pos:  position{line: 399, col: 23, offset: 12921},
+													name: "LEFT_BRACE",
+												},
+											},
+											&ruleRefExpr{
+												pos:  position{line: 399, col: 34, offset: 12932},
+												name: "RecoveryToken",
+											},
+										},
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 399, col: 51, offset: 12949},
+									name: "BraceGroup",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 401, col: 5, offset: 12983},
+						run: (*parser).callonDeclaration16,
+						expr: &seqExpr{
+							pos: position{line: 401, col: 5, offset: 12983},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 401, col: 5, offset: 12983},
+									name: "DeclarationKind",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 401, col: 21, offset: 12999},
+									name: "Synchronize",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 403, col: 5, offset: 13034},
+						run: (*parser).callonDeclaration20,
+						expr: &seqExpr{
+							pos: position{line: 403, col: 5, offset: 13034},
+							exprs: []any{
+								&oneOrMoreExpr{
+									pos: position{line: 403, col: 5, offset: 13034},
+									expr: &ruleRefExpr{
+										pos:  position{line: 403, col: 5, offset: 13034},
+										name: "RecoveryToken",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 403, col: 20, offset: 13049},
+									name: "Synchronize",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "DeclarationKind",
+			pos:  position{line: 407, col: 1, offset: 13120},
+			expr: &choiceExpr{
+				pos: position{line: 408, col: 4, offset: 13139},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 346, col: 4, offset: 10520},
+						pos:  position{line: 408, col: 4, offset: 13139},
 						name: "ClassDeclaration",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 347, col: 4, offset: 10541},
+						pos:  position{line: 409, col: 4, offset: 13159},
 						name: "FunDeclaration",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 348, col: 4, offset: 10560},
+						pos:  position{line: 410, col: 4, offset: 13177},
 						name: "VarDeclaration",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 349, col: 4, offset: 10579},
+						pos:  position{line: 411, col: 4, offset: 13195},
 						name: "StatementDeclaration",
 					},
 				},
 			},
 		},
+		{
+			name: "Synchronize",
+			pos:  position{line: 416, col: 1, offset: 13406},
+			expr: &seqExpr{
+				pos: position{line: 416, col: 15, offset: 13420},
+				exprs: []any{
+					&zeroOrMoreExpr{
+						pos: position{line: 416, col: 15, offset: 13420},
+						expr: &ruleRefExpr{
+							pos:  position{line: 416, col: 15, offset: 13420},
+							name: "RecoveryToken",
+						},
+					},
+					&zeroOrOneExpr{
+						pos: position{line: 416, col: 30, offset: 13435},
+						expr: &ruleRefExpr{
+							pos:  position{line: 416, col: 30, offset: 13435},
+							name: "SEMICOLON",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "RecoveryToken",
+			pos:  position{line: 418, col: 1, offset: 13447},
+			expr: &seqExpr{
+				pos: position{line: 418, col: 17, offset: 13463},
+				exprs: []any{
+					&notExpr{
+						pos: position{line: 418, col: 17, offset: 13463},
+						expr: &choiceExpr{
+							pos: position{line: 418, col: 20, offset: 13466},
+							alternatives: []any{
+								&ruleRefExpr{
+									pos:  position{line: 418, col: 20, offset: 13466},
+									name: "SEMICOLON",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 418, col: 32, offset: 13478},
+									name: "RIGHT_BRACE",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 418, col: 46, offset: 13492},
+									name: "SYNCHRONIZING_KEYWORD",
+								},
+							},
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 418, col: 70, offset: 13516},
+						name: "AnyToken",
+					},
+				},
+			},
+		},
+		{
+			name: "AnyToken",
+			pos:  position{line: 420, col: 1, offset: 13526},
+			expr: &choiceExpr{
+				pos: position{line: 420, col: 12, offset: 13537},
+				alternatives: []any{
+					&ruleRefExpr{
+						pos:  position{line: 420, col: 12, offset: 13537},
+						name: "STRING",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 420, col: 21, offset: 13546},
+						name: "NUMBER",
+					},
+					&seqExpr{
+						pos: position{line: 420, col: 30, offset: 13555},
+						exprs: []any{
+							&ruleRefExpr{
+								pos:  position{line: 420, col: 30, offset: 13555},
+								name: "_",
+							},
+							&ruleRefExpr{
+								pos:  position{line: 420, col: 32, offset: 13557},
+								name: "ALPHA",
+							},
+							&zeroOrMoreExpr{
+								pos: position{line: 420, col: 38, offset: 13563},
+								expr: &choiceExpr{
+									pos: position{line: 420, col: 40, offset: 13565},
+									alternatives: []any{
+										&ruleRefExpr{
+											pos:  position{line: 420, col: 40, offset: 13565},
+											name: "ALPHA",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 420, col: 48, offset: 13573},
+											name: "DIGIT",
+										},
+									},
+								},
+							},
+						},
+					},
+					&seqExpr{
+						pos: position{line: 420, col: 59, offset: 13584},
+						exprs: []any{
+							&ruleRefExpr{
+								pos:  position{line: 420, col: 59, offset: 13584},
+								name: "_",
+							},
+							&anyMatcher{
+								line: 420, col: 61, offset: 13586,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SYNCHRONIZING_KEYWORD",
+			pos:  position{line: 422, col: 1, offset: 13589},
+			expr: &seqExpr{
+				pos: position{line: 422, col: 25, offset: 13613},
+				exprs: []any{
+					&ruleRefExpr{
+						pos:  position{line: 422, col: 25, offset: 13613},
+						name: "_",
+					},
+					&choiceExpr{
+						pos: position{line: 422, col: 29, offset: 13617},
+						alternatives: []any{
+							&litMatcher{
+								pos:        position{line: 422, col: 29, offset: 13617},
+								val:        "class",
+								ignoreCase: false,
+								want:       "\"class\"",
+							},
+							&litMatcher{
+								pos:        position{line: 422, col: 39, offset: 13627},
+								val:        "fun",
+								ignoreCase: false,
+								want:       "\"fun\"",
+							},
+							&litMatcher{
+								pos:        position{line: 422, col: 47, offset: 13635},
+								val:        "var",
+								ignoreCase: false,
+								want:       "\"var\"",
+							},
+							&litMatcher{
+								pos:        position{line: 422, col: 55, offset: 13643},
+								val:        "for",
+								ignoreCase: false,
+								want:       "\"for\"",
+							},
+							&litMatcher{
+								pos:        position{line: 422, col: 63, offset: 13651},
+								val:        "if",
+								ignoreCase: false,
+								want:       "\"if\"",
+							},
+							&litMatcher{
+								pos:        position{line: 422, col: 70, offset: 13658},
+								val:        "while",
+								ignoreCase: false,
+								want:       "\"while\"",
+							},
+							&litMatcher{
+								pos:        position{line: 422, col: 80, offset: 13668},
+								val:        "print",
+								ignoreCase: false,
+								want:       "\"print\"",
+							},
+							&litMatcher{
+								pos:        position{line: 422, col: 90, offset: 13678},
+								val:        "return",
+								ignoreCase: false,
+								want:       "\"return\"",
+							},
+						},
+					},
+					&ruleRefExpr{
+						pos:  position{line: 422, col: 101, offset: 13689},
+						name: "WORD_BOUNDARY",
+					},
+				},
+			},
+		},
 		{
 			name: "StatementDeclaration",
-			pos:  position{line: 351, col: 1, offset: 10603},
+			pos:  position{line: 424, col: 1, offset: 13704},
 			expr: &actionExpr{
-				pos: position{line: 351, col: 24, offset: 10626},
+				pos: position{line: 424, col: 24, offset: 13727},
 				run: (*parser).callonStatementDeclaration1,
 				expr: &labeledExpr{
-					pos:   position{line: 351, col: 24, offset: 10626},
+					pos:   position{line: 424, col: 24, offset: 13727},
 					label: "s",
 					expr: &ruleRefExpr{
-						pos:  position{line: 351, col: 26, offset: 10628},
+						pos:  position{line: 424, col: 26, offset: 13729},
 						name: "Statement",
 					},
 				},
@@ -2780,42 +3419,42 @@ var g = &grammar{
 		},
 		{
 			name: "ClassDeclaration",
-			pos:  position{line: 358, col: 1, offset: 10800},
+			pos:  position{line: 432, col: 1, offset: 13931},
 			expr: &choiceExpr{
-				pos: position{line: 358, col: 20, offset: 10819},
+				pos: position{line: 432, col: 20, offset: 13950},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 358, col: 20, offset: 10819},
+						pos: position{line: 432, col: 20, offset: 13950},
 						run: (*parser).callonClassDeclaration2,
 						expr: &seqExpr{
-							pos: position{line: 358, col: 20, offset: 10819},
+							pos: position{line: 432, col: 20, offset: 13950},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 358, col: 20, offset: 10819},
+									pos:  position{line: 432, col: 20, offset: 13950},
 									name: "CLASS",
 								},
 								&labeledExpr{
-									pos:   position{line: 358, col: 26, offset: 10825},
+									pos:   position{line: 432, col: 26, offset: 13956},
 									label: "i",
 									expr: &ruleRefExpr{
-										pos:  position{line: 358, col: 28, offset: 10827},
+										pos:  position{line: 432, col: 28, offset: 13958},
 										name: "IDENTIFIER",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 358, col: 39, offset: 10838},
+									pos:   position{line: 432, col: 39, offset: 13969},
 									label: "ext",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 358, col: 43, offset: 10842},
+										pos: position{line: 432, col: 43, offset: 13973},
 										expr: &seqExpr{
-											pos: position{line: 358, col: 44, offset: 10843},
+											pos: position{line: 432, col: 44, offset: 13974},
 											exprs: []any{
 												&ruleRefExpr{
-													pos:  position{line: 358, col: 44, offset: 10843},
+													pos:  position{line: 432, col: 44, offset: 13974},
 													name: "LESS",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 358, col: 49, offset: 10848},
+													pos:  position{line: 432, col: 49, offset: 13979},
 													name: "IDENTIFIER",
 												},
 											},
@@ -2823,159 +3462,159 @@ var g = &grammar{
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 358, col: 62, offset: 10861},
+									pos:  position{line: 432, col: 62, offset: 13992},
 									name: "LEFT_BRACE",
 								},
 								&labeledExpr{
-									pos:   position{line: 358, col: 73, offset: 10872},
+									pos:   position{line: 432, col: 73, offset: 14003},
 									label: "m",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 358, col: 75, offset: 10874},
+										pos: position{line: 432, col: 75, offset: 14005},
 										expr: &ruleRefExpr{
-											pos:  position{line: 358, col: 75, offset: 10874},
-											name: "function",
+											pos:  position{line: 432, col: 75, offset: 14005},
+											name: "Method",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 358, col: 85, offset: 10884},
+									pos:  position{line: 432, col: 83, offset: 14013},
 									name: "RIGHT_BRACE",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 372, col: 5, offset: 11264},
+						pos: position{line: 449, col: 5, offset: 14481},
 						run: (*parser).callonClassDeclaration17,
 						expr: &seqExpr{
-							pos: position{line: 372, col: 5, offset: 11264},
+							pos: position{line: 449, col: 5, offset: 14481},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 372, col: 5, offset: 11264},
+									pos:  position{line: 449, col: 5, offset: 14481},
 									name: "CLASS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 372, col: 11, offset: 11270},
+									pos:  position{line: 449, col: 11, offset: 14487},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 372, col: 22, offset: 11281},
+									pos:  position{line: 449, col: 22, offset: 14498},
 									name: "LESS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 372, col: 27, offset: 11286},
+									pos:  position{line: 449, col: 27, offset: 14503},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 372, col: 38, offset: 11297},
+									pos:  position{line: 449, col: 38, offset: 14514},
 									name: "LEFT_BRACE",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 372, col: 49, offset: 11308},
+									pos: position{line: 449, col: 49, offset: 14525},
 									expr: &ruleRefExpr{
-										pos:  position{line: 372, col: 49, offset: 11308},
-										name: "function",
+										pos:  position{line: 449, col: 49, offset: 14525},
+										name: "Method",
 									},
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 374, col: 5, offset: 11388},
+						pos: position{line: 451, col: 5, offset: 14601},
 						run: (*parser).callonClassDeclaration26,
 						expr: &seqExpr{
-							pos: position{line: 374, col: 5, offset: 11388},
+							pos: position{line: 451, col: 5, offset: 14601},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 374, col: 5, offset: 11388},
+									pos:  position{line: 451, col: 5, offset: 14601},
 									name: "CLASS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 374, col: 11, offset: 11394},
+									pos:  position{line: 451, col: 11, offset: 14607},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 374, col: 22, offset: 11405},
+									pos:  position{line: 451, col: 22, offset: 14618},
 									name: "LESS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 374, col: 27, offset: 11410},
+									pos:  position{line: 451, col: 27, offset: 14623},
 									name: "IDENTIFIER",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 376, col: 5, offset: 11490},
+						pos: position{line: 453, col: 5, offset: 14701},
 						run: (*parser).callonClassDeclaration32,
 						expr: &seqExpr{
-							pos: position{line: 376, col: 5, offset: 11490},
+							pos: position{line: 453, col: 5, offset: 14701},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 376, col: 5, offset: 11490},
+									pos:  position{line: 453, col: 5, offset: 14701},
 									name: "CLASS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 376, col: 11, offset: 11496},
+									pos:  position{line: 453, col: 11, offset: 14707},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 376, col: 22, offset: 11507},
+									pos:  position{line: 453, col: 22, offset: 14718},
 									name: "LESS",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 378, col: 5, offset: 11568},
+						pos: position{line: 455, col: 5, offset: 14777},
 						run: (*parser).callonClassDeclaration37,
 						expr: &seqExpr{
-							pos: position{line: 378, col: 5, offset: 11568},
+							pos: position{line: 455, col: 5, offset: 14777},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 378, col: 5, offset: 11568},
+									pos:  position{line: 455, col: 5, offset: 14777},
 									name: "CLASS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 378, col: 11, offset: 11574},
+									pos:  position{line: 455, col: 11, offset: 14783},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 378, col: 22, offset: 11585},
+									pos:  position{line: 455, col: 22, offset: 14794},
 									name: "LEFT_BRACE",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 378, col: 33, offset: 11596},
+									pos: position{line: 455, col: 33, offset: 14805},
 									expr: &ruleRefExpr{
-										pos:  position{line: 378, col: 33, offset: 11596},
-										name: "function",
+										pos:  position{line: 455, col: 33, offset: 14805},
+										name: "Method",
 									},
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 380, col: 5, offset: 11676},
+						pos: position{line: 457, col: 5, offset: 14881},
 						run: (*parser).callonClassDeclaration44,
 						expr: &seqExpr{
-							pos: position{line: 380, col: 5, offset: 11676},
+							pos: position{line: 457, col: 5, offset: 14881},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 380, col: 5, offset: 11676},
+									pos:  position{line: 457, col: 5, offset: 14881},
 									name: "CLASS",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 380, col: 11, offset: 11682},
+									pos:  position{line: 457, col: 11, offset: 14887},
 									name: "IDENTIFIER",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 382, col: 5, offset: 11762},
+						pos: position{line: 459, col: 5, offset: 14965},
 						run: (*parser).callonClassDeclaration48,
 						expr: &ruleRefExpr{
-							pos:  position{line: 382, col: 5, offset: 11762},
+							pos:  position{line: 459, col: 5, offset: 14965},
 							name: "CLASS",
 						},
 					},
@@ -2984,67 +3623,263 @@ var g = &grammar{
 		},
 		{
 			name: "FunDeclaration",
-			pos:  position{line: 386, col: 1, offset: 11821},
-			expr: &actionExpr{
-				pos: position{line: 386, col: 18, offset: 11838},
-				run: (*parser).callonFunDeclaration1,
-				expr: &seqExpr{
-					pos: position{line: 386, col: 18, offset: 11838},
-					exprs: []any{
-						&ruleRefExpr{
-							pos:  position{line: 386, col: 18, offset: 11838},
+			pos:  position{line: 463, col: 1, offset: 15020},
+			expr: &choiceExpr{
+				pos: position{line: 463, col: 18, offset: 15037},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 463, col: 18, offset: 15037},
+						run: (*parser).callonFunDeclaration2,
+						expr: &seqExpr{
+							pos: position{line: 463, col: 18, offset: 15037},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 463, col: 18, offset: 15037},
+									name: "FUN",
+								},
+								&labeledExpr{
+									pos:   position{line: 463, col: 22, offset: 15041},
+									label: "f",
+									expr: &ruleRefExpr{
+										pos:  position{line: 463, col: 24, offset: 15043},
+										name: "function",
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 470, col: 5, offset: 15213},
+						run: (*parser).callonFunDeclaration7,
+						expr: &ruleRefExpr{
+							pos:  position{line: 470, col: 5, offset: 15213},
 							name: "FUN",
 						},
-						&labeledExpr{
-							pos:   position{line: 386, col: 22, offset: 11842},
-							label: "f",
-							expr: &ruleRefExpr{
-								pos:  position{line: 386, col: 24, offset: 11844},
-								name: "function",
+					},
+				},
+			},
+		},
+		{
+			name: "Method",
+			pos:  position{line: 477, col: 1, offset: 15416},
+			expr: &choiceExpr{
+				pos: position{line: 477, col: 10, offset: 15425},
+				alternatives: []any{
+					&actionExpr{
+						pos: position{line: 477, col: 10, offset: 15425},
+						run: (*parser).callonMethod2,
+						expr: &seqExpr{
+							pos: position{line: 477, col: 10, offset: 15425},
+							exprs: []any{
+								&labeledExpr{
+									pos:   position{line: 477, col: 10, offset: 15425},
+									label: "f",
+									expr: &ruleRefExpr{
+										pos:  position{line: 477, col: 12, offset: 15427},
+										name: "function",
+									},
+								},
+								&andCodeExpr{
+									pos: position{line: 477, col: 21, offset: 15436},
+									run: (*parser).callonMethod6,
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 479, col: 5, offset: 15483},
+						run: (*parser).callonMethod7,
+						expr: &seqExpr{
+							pos: position{line: 479, col: 5, offset: 15483},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 479, col: 5, offset: 15483},
+									name: "function",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 479, col: 14, offset: 15492},
+									name: "SkipMethod",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 481, col: 5, offset: 15571},
+						run: (*parser).callonMethod11,
+						expr: &seqExpr{
+							pos: position{line: 481, col: 5, offset: 15571},
+							exprs: []any{
+								&notExpr{
+									pos: position{line: 481, col: 5, offset: 15571},
+									expr: &ruleRefExpr{
+										pos:  position{line: 481, col: 6, offset: 15572},
+										name: "RIGHT_BRACE",
+									},
+								},
+								&andExpr{
+									pos: position{line: 481, col: 18, offset: 15584},
+									expr: &seqExpr{
+										pos: position{line: 481, col: 21, offset: 15587},
+										exprs: []any{
+											&ruleRefExpr{
+												pos:  position{line: 481, col: 21, offset: 15587},
+												name: "_",
+											},
+											&anyMatcher{
+												line: 481, col: 23, offset: 15589,
+											},
+										},
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 481, col: 27, offset: 15593},
+									name: "SkipMethod",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "SkipMethod",
+			pos:  position{line: 488, col: 1, offset: 15860},
+			expr: &seqExpr{
+				pos: position{line: 488, col: 14, offset: 15873},
+				exprs: []any{
+					&zeroOrMoreExpr{
+						pos: position{line: 488, col: 14, offset: 15873},
+						expr: &seqExpr{
+							pos: position{line: 488, col: 16, offset: 15875},
+							exprs: []any{
+								&notExpr{
+									pos: position{line: 488, col: 16, offset: 15875},
+									expr: &choiceExpr{
+										pos: position{line: 488, col: 19, offset: 15878},
+										alternatives: []any{
+											&ruleRefExpr{
+												pos:  position{line: 488, col: 19, offset: 15878},
+												name: "LEFT_BRACE",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 488, col: 32, offset: 15891},
+												name: "RIGHT_BRACE",
+											},
+										},
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 488, col: 46, offset: 15905},
+									name: "AnyToken",
+								},
+							},
+						},
+					},
+					&zeroOrOneExpr{
+						pos: position{line: 488, col: 58, offset: 15917},
+						expr: &ruleRefExpr{
+							pos:  position{line: 488, col: 58, offset: 15917},
+							name: "BraceGroup",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BraceGroup",
+			pos:  position{line: 490, col: 1, offset: 15930},
+			expr: &seqExpr{
+				pos: position{line: 490, col: 14, offset: 15943},
+				exprs: []any{
+					&ruleRefExpr{
+						pos:  position{line: 490, col: 14, offset: 15943},
+						name: "LEFT_BRACE",
+					},
+					&zeroOrMoreExpr{
+						pos: position{line: 490, col: 25, offset: 15954},
+						expr: &choiceExpr{
+							pos: position{line: 490, col: 27, offset: 15956},
+							alternatives: []any{
+								&ruleRefExpr{
+									pos:  position{line: 490, col: 27, offset: 15956},
+									name: "BraceGroup",
+								},
+								&seqExpr{
+									pos: position{line: 490, col: 40, offset: 15969},
+									exprs: []any{
+										&notExpr{
+											pos: position{line: 490, col: 40, offset: 15969},
+											expr: &choiceExpr{
+												pos: position{line: 490, col: 43, offset: 15972},
+												alternatives: []any{
+													&ruleRefExpr{
+														pos:  position{line: 490, col: 43, offset: 15972},
+														name: "LEFT_BRACE",
+													},
+													&ruleRefExpr{
+														pos:  position{line: 490, col: 56, offset: 15985},
+														name: "RIGHT_BRACE",
+													},
+												},
+											},
+										},
+										&ruleRefExpr{
+											pos:  position{line: 490, col: 70, offset: 15999},
+											name: "AnyToken",
+										},
+									},
+								},
 							},
 						},
 					},
+					&zeroOrOneExpr{
+						pos: position{line: 490, col: 82, offset: 16011},
+						expr: &ruleRefExpr{
+							pos:  position{line: 490, col: 82, offset: 16011},
+							name: "RIGHT_BRACE",
+						},
+					},
 				},
 			},
 		},
 		{
 			name: "VarDeclaration",
-			pos:  position{line: 388, col: 1, offset: 11874},
+			pos:  position{line: 492, col: 1, offset: 16025},
 			expr: &choiceExpr{
-				pos: position{line: 388, col: 18, offset: 11891},
+				pos: position{line: 492, col: 18, offset: 16042},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 388, col: 18, offset: 11891},
+						pos: position{line: 492, col: 18, offset: 16042},
 						run: (*parser).callonVarDeclaration2,
 						expr: &seqExpr{
-							pos: position{line: 388, col: 18, offset: 11891},
+							pos: position{line: 492, col: 18, offset: 16042},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 388, col: 18, offset: 11891},
+									pos:  position{line: 492, col: 18, offset: 16042},
 									name: "VAR",
 								},
 								&labeledExpr{
-									pos:   position{line: 388, col: 22, offset: 11895},
+									pos:   position{line: 492, col: 22, offset: 16046},
 									label: "i",
 									expr: &ruleRefExpr{
-										pos:  position{line: 388, col: 24, offset: 11897},
+										pos:  position{line: 492, col: 24, offset: 16048},
 										name: "IDENTIFIER",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 388, col: 35, offset: 11908},
+									pos:   position{line: 492, col: 35, offset: 16059},
 									label: "init",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 388, col: 40, offset: 11913},
+										pos: position{line: 492, col: 40, offset: 16064},
 										expr: &seqExpr{
-											pos: position{line: 388, col: 41, offset: 11914},
+											pos: position{line: 492, col: 41, offset: 16065},
 											exprs: []any{
 												&ruleRefExpr{
-													pos:  position{line: 388, col: 41, offset: 11914},
+													pos:  position{line: 492, col: 41, offset: 16065},
 													name: "EQUAL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 388, col: 47, offset: 11920},
+													pos:  position{line: 492, col: 47, offset: 16071},
 													name: "Expression",
 												},
 											},
@@ -3052,100 +3887,194 @@ var g = &grammar{
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 388, col: 60, offset: 11933},
+									pos:  position{line: 492, col: 60, offset: 16084},
 									name: "SEMICOLON",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 396, col: 5, offset: 12114},
+						pos: position{line: 501, col: 5, offset: 16276},
 						run: (*parser).callonVarDeclaration13,
 						expr: &seqExpr{
-							pos: position{line: 396, col: 5, offset: 12114},
+							pos: position{line: 501, col: 5, offset: 16276},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 396, col: 5, offset: 12114},
+									pos:  position{line: 501, col: 5, offset: 16276},
 									name: "VAR",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 396, col: 9, offset: 12118},
+									pos:  position{line: 501, col: 9, offset: 16280},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 396, col: 20, offset: 12129},
+									pos:  position{line: 501, col: 20, offset: 16291},
 									name: "EQUAL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 396, col: 26, offset: 12135},
+									pos:  position{line: 501, col: 26, offset: 16297},
 									name: "Expression",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 398, col: 5, offset: 12197},
+						pos: position{line: 503, col: 5, offset: 16357},
 						run: (*parser).callonVarDeclaration19,
 						expr: &seqExpr{
-							pos: position{line: 398, col: 5, offset: 12197},
+							pos: position{line: 503, col: 5, offset: 16357},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 398, col: 5, offset: 12197},
+									pos:  position{line: 503, col: 5, offset: 16357},
 									name: "VAR",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 398, col: 9, offset: 12201},
+									pos:  position{line: 503, col: 9, offset: 16361},
 									name: "IDENTIFIER",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 398, col: 20, offset: 12212},
+									pos:  position{line: 503, col: 20, offset: 16372},
 									name: "EQUAL",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 400, col: 5, offset: 12270},
+						pos: position{line: 505, col: 5, offset: 16428},
 						run: (*parser).callonVarDeclaration24,
 						expr: &seqExpr{
-							pos: position{line: 400, col: 5, offset: 12270},
+							pos: position{line: 505, col: 5, offset: 16428},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 400, col: 5, offset: 12270},
+									pos:  position{line: 505, col: 5, offset: 16428},
 									name: "VAR",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 400, col: 9, offset: 12274},
+									pos:  position{line: 505, col: 9, offset: 16432},
 									name: "IDENTIFIER",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 402, col: 5, offset: 12336},
+						pos: position{line: 507, col: 5, offset: 16492},
 						run: (*parser).callonVarDeclaration28,
 						expr: &ruleRefExpr{
-							pos:  position{line: 402, col: 5, offset: 12336},
+							pos:  position{line: 507, col: 5, offset: 16492},
 							name: "VAR",
 						},
 					},
 				},
 			},
 		},
+		{
+			name: "StraySemicolon",
+			pos:  position{line: 516, col: 1, offset: 16766},
+			expr: &actionExpr{
+				pos: position{line: 516, col: 18, offset: 16783},
+				run: (*parser).callonStraySemicolon1,
+				expr: &ruleRefExpr{
+					pos:  position{line: 516, col: 18, offset: 16783},
+					name: "SEMICOLON",
+				},
+			},
+		},
+		{
+			name: "StrayToken",
+			pos:  position{line: 520, col: 1, offset: 16828},
+			expr: &choiceExpr{
+				pos: position{line: 520, col: 14, offset: 16841},
+				alternatives: []any{
+					&ruleRefExpr{
+						pos:  position{line: 520, col: 14, offset: 16841},
+						name: "StraySemicolon",
+					},
+					&actionExpr{
+						pos: position{line: 520, col: 31, offset: 16858},
+						run: (*parser).callonStrayToken3,
+						expr: &ruleRefExpr{
+							pos:  position{line: 520, col: 31, offset: 16858},
+							name: "RIGHT_BRACE",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Program",
-			pos:  position{line: 408, col: 1, offset: 12451},
+			pos:  position{line: 524, col: 1, offset: 16905},
 			expr: &actionExpr{
-				pos: position{line: 408, col: 11, offset: 12461},
+				pos: position{line: 524, col: 11, offset: 16915},
 				run: (*parser).callonProgram1,
-				expr: &labeledExpr{
-					pos:   position{line: 408, col: 11, offset: 12461},
-					label: "d",
-					expr: &zeroOrMoreExpr{
-						pos: position{line: 408, col: 13, offset: 12463},
-						expr: &ruleRefExpr{
-							pos:  position{line: 408, col: 13, offset: 12463},
-							name: "Declaration",
+				expr: &seqExpr{
+					pos: position{line: 524, col: 11, offset: 16915},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 524, col: 11, offset: 16915},
+							label: "d",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 524, col: 13, offset: 16917},
+								expr: &choiceExpr{
+									pos: position{line: 524, col: 15, offset: 16919},
+									alternatives: []any{
+										&ruleRefExpr{
+											pos:  position{line: 524, col: 15, offset: 16919},
+											name: "Declaration",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 524, col: 29, offset: 16933},
+											name: "StrayToken",
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 524, col: 43, offset: 16947},
+							name: "EOF",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "EOF",
+			pos:  position{line: 528, col: 1, offset: 16987},
+			expr: &choiceExpr{
+				pos: position{line: 528, col: 7, offset: 16993},
+				alternatives: []any{
+					&seqExpr{
+						pos: position{line: 528, col: 7, offset: 16993},
+						exprs: []any{
+							&ruleRefExpr{
+								pos:  position{line: 528, col: 7, offset: 16993},
+								name: "_",
+							},
+							&notExpr{
+								pos: position{line: 528, col: 9, offset: 16995},
+								expr: &anyMatcher{
+									line: 528, col: 10, offset: 16996,
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 528, col: 14, offset: 17000},
+						run: (*parser).callonEOF6,
+						expr: &seqExpr{
+							pos: position{line: 528, col: 14, offset: 17000},
+							exprs: []any{
+								&ruleRefExpr{
+									pos:  position{line: 528, col: 14, offset: 17000},
+									name: "_",
+								},
+								&oneOrMoreExpr{
+									pos: position{line: 528, col: 16, offset: 17002},
+									expr: &anyMatcher{
+										line: 528, col: 16, offset: 17002,
+									},
+								},
+							},
 						},
 					},
 				},
@@ -3154,10 +4083,19 @@ var g = &grammar{
 	},
 }
 
-func (c *current) onIDENTIFIER1() (any, error) {
+func (c *current) onBlockComment12() (any, error) {
+	return nil, c.throwUnterminated(0, "unterminated block comment")
+}
 
+func (p *parser) callonBlockComment12() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onBlockComment12()
+}
+
+func (c *current) onIDENTIFIER1() (any, error) {
 	str := matchedTextOf(c)
-	return ast.Identifier(str), nil
+	return ast.Identifier{Span: spanOf(c), Name: str}, nil
 }
 
 func (p *parser) callonIDENTIFIER1() (any, error) {
@@ -3166,26 +4104,28 @@ func (p *parser) callonIDENTIFIER1() (any, error) {
 	return p.cur.onIDENTIFIER1()
 }
 
-func (c *current) onSTRING1() (any, error) {
+func (c *current) onSTRING2() (any, error) {
+	return stringLiteral(c)
+}
 
-	str := matchedTextOf(c)
-	return ast.StringLiteral(str), nil
+func (p *parser) callonSTRING2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onSTRING2()
+}
+
+func (c *current) onSTRING13() (any, error) {
+	return ast.StringLiteral{Span: spanOf(c)}, c.throwUnterminated(skipTrivia(string(c.text)), "unterminated string")
 }
 
-func (p *parser) callonSTRING1() (any, error) {
+func (p *parser) callonSTRING13() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onSTRING1()
+	return p.cur.onSTRING13()
 }
 
 func (c *current) onNUMBER1() (any, error) {
-
-	str := matchedTextOf(c)
-	n, err := strconv.ParseFloat(str, 64)
-	if err != nil {
-		return nil, err
-	}
-	return ast.NumberLiteral(n), nil
+	return numberLiteral(c)
 }
 
 func (p *parser) callonNUMBER1() (any, error) {
@@ -3544,8 +4484,21 @@ func (p *parser) callonWHILE1() (any, error) {
 	return p.cur.onWHILE1()
 }
 
-func (c *current) onarguments1(pat any) (any, error) {
+func (c *current) oninvocation1(args any) (any, error) {
+	inv := invocation{end: spanOf(c).End}
+	if args != nil {
+		inv.arguments = args.([]ast.Expression)
+	}
+	return inv, nil
+}
+
+func (p *parser) calloninvocation1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.oninvocation1(stack["args"])
+}
 
+func (c *current) onarguments1(pat any) (any, error) {
 	var args []ast.Expression
 
 	p := pat.([]any)
@@ -3564,7 +4517,6 @@ func (p *parser) callonarguments1() (any, error) {
 }
 
 func (c *current) onparameters1(pat any) (any, error) {
-
 	var idents []ast.Identifier
 
 	p := pat.([]any)
@@ -3583,12 +4535,18 @@ func (p *parser) callonparameters1() (any, error) {
 }
 
 func (c *current) onfunction2(name, params, body any) (any, error) {
-
-	return &ast.FunDeclaration{
-		Name:       name.(ast.Identifier),
-		Parameters: params.([]ast.Identifier),
-		Body:       body.(*ast.BlockStatement),
-	}, nil
+	if body == nil {
+		return nil, nil // errors are reported earlier. just return.
+	}
+	decl := &ast.FunDeclaration{
+		Span: spanOf(c),
+		Name: name.(ast.Identifier),
+		Body: body.(*ast.BlockStatement),
+	}
+	if params != nil {
+		decl.Parameters = params.([]ast.Identifier)
+	}
+	return decl, nil
 }
 
 func (p *parser) callonfunction2() (any, error) {
@@ -3598,7 +4556,6 @@ func (p *parser) callonfunction2() (any, error) {
 }
 
 func (c *current) onfunction13() (any, error) {
-
 	return nil, c.throw("expected function body block")
 }
 
@@ -3608,41 +4565,38 @@ func (p *parser) callonfunction13() (any, error) {
 	return p.cur.onfunction13()
 }
 
-func (c *current) onfunction19() (any, error) {
-
+func (c *current) onfunction20() (any, error) {
 	return nil, c.throw("expected right parenthesis")
 }
 
-func (p *parser) callonfunction19() (any, error) {
+func (p *parser) callonfunction20() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onfunction19()
+	return p.cur.onfunction20()
 }
 
-func (c *current) onfunction24() (any, error) {
-
+func (c *current) onfunction25() (any, error) {
 	return nil, c.throw("expected parameters or right parenthesis")
 }
 
-func (p *parser) callonfunction24() (any, error) {
+func (p *parser) callonfunction25() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onfunction24()
+	return p.cur.onfunction25()
 }
 
-func (c *current) onfunction28() (any, error) {
-
+func (c *current) onfunction29() (any, error) {
 	return nil, c.throw("expected left parenthesis")
 }
 
-func (p *parser) callonfunction28() (any, error) {
+func (p *parser) callonfunction29() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onfunction28()
+	return p.cur.onfunction29()
 }
 
 func (c *current) onPrimary2() (any, error) {
-	return ast.BooleanLiteral(true), nil
+	return ast.BooleanLiteral{Span: spanOf(c), Value: true}, nil
 }
 
 func (p *parser) callonPrimary2() (any, error) {
@@ -3652,7 +4606,7 @@ func (p *parser) callonPrimary2() (any, error) {
 }
 
 func (c *current) onPrimary4() (any, error) {
-	return ast.BooleanLiteral(false), nil
+	return ast.BooleanLiteral{Span: spanOf(c), Value: false}, nil
 }
 
 func (p *parser) callonPrimary4() (any, error) {
@@ -3662,7 +4616,7 @@ func (p *parser) callonPrimary4() (any, error) {
 }
 
 func (c *current) onPrimary6() (any, error) {
-	return ast.Nil{}, nil
+	return ast.Nil{Span: spanOf(c)}, nil
 }
 
 func (p *parser) callonPrimary6() (any, error) {
@@ -3672,7 +4626,7 @@ func (p *parser) callonPrimary6() (any, error) {
 }
 
 func (c *current) onPrimary8() (any, error) {
-	return ast.This{}, nil
+	return ast.This{Span: spanOf(c)}, nil
 }
 
 func (p *parser) callonPrimary8() (any, error) {
@@ -3702,7 +4656,9 @@ func (p *parser) callonPrimary13() (any, error) {
 }
 
 func (c *current) onPrimary16(i any) (any, error) {
-	return i, nil
+	ident := i.(ast.Identifier)
+	return &ident, nil
+
 }
 
 func (p *parser) callonPrimary16() (any, error) {
@@ -3712,7 +4668,6 @@ func (p *parser) callonPrimary16() (any, error) {
 }
 
 func (c *current) onPrimary19(e any) (any, error) {
-
 	return e, nil
 
 }
@@ -3724,9 +4679,10 @@ func (p *parser) callonPrimary19() (any, error) {
 }
 
 func (c *current) onPrimary25(i any) (any, error) {
-
+	span := spanOf(c)
 	return &ast.PropertyAccessExpression{
-		Target:   ast.Super{},
+		Span:     span,
+		Target:   ast.Super{Span: ast.Span{Start: span.Start, End: span.Start + len("super")}},
 		Property: i.(ast.Identifier),
 	}, nil
 
@@ -3739,20 +4695,22 @@ func (p *parser) callonPrimary25() (any, error) {
 }
 
 func (c *current) onCall1(e, pat any) (any, error) {
-
+	start := spanOf(c).Start
 	expr := e.(ast.Expression)
 	for _, p := range pat.([]any) {
-		pattern := p.([]any)
-		switch pattern[0].(TokenKind) {
-		case TokLeftParenthesis:
+		switch pattern := p.(type) {
+		case invocation:
 			expr = &ast.InvocationExpression{
+				Span:      ast.Span{Start: start, End: pattern.end},
 				Callee:    expr,
-				Arguments: pattern[1].([]ast.Expression),
+				Arguments: pattern.arguments,
 			}
-		case TokDot:
+		case []any:
+			property := pattern[1].(ast.Identifier)
 			expr = &ast.PropertyAccessExpression{
+				Span:     ast.Span{Start: start, End: property.End},
 				Target:   expr,
-				Property: pattern[1].(ast.Identifier),
+				Property: property,
 			}
 		default:
 			panic("unreachable case in peg::grammar::Call")
@@ -3768,7 +4726,6 @@ func (p *parser) callonCall1() (any, error) {
 }
 
 func (c *current) onUnary2(op, u any) (any, error) {
-
 	var operator ast.UnaryOperator
 	switch op.(TokenKind) {
 	case TokBang:
@@ -3779,6 +4736,7 @@ func (c *current) onUnary2(op, u any) (any, error) {
 		panic("unreachable case in peg::grammar::Unary")
 	}
 	return &ast.UnaryExpression{
+		Span:     spanOf(c),
 		Operator: operator,
 		Operand:  u.(ast.Expression),
 	}, nil
@@ -3850,36 +4808,31 @@ func (p *parser) callonLogicalOr1() (any, error) {
 	return p.cur.onLogicalOr1(stack["l"], stack["pat"])
 }
 
-func (c *current) onAssignment2(prev, i, e any) (any, error) {
-
-	var expr ast.Expression
-	if prev != nil {
-		previous := prev.([]any)
-		expr = &ast.PropertyAccessExpression{
-			Target:   previous[0].(ast.Expression),
-			Property: i.(ast.Identifier),
-		}
-	} else {
-		expr = i.(ast.Expression)
-	}
-	return &ast.AssignmentExpression{
-		Target: expr,
-		Value:  e.(ast.Expression),
-	}, nil
+func (c *current) onAssignment2(target, value any) (any, error) {
+	return assignment(c, target, value)
 }
 
 func (p *parser) callonAssignment2() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onAssignment2(stack["prev"], stack["i"], stack["e"])
+	return p.cur.onAssignment2(stack["target"], stack["value"])
 }
 
-func (c *current) onExpressionStatement2(e any) (any, error) {
+func (c *current) onAssignment9(target, value any) (any, error) {
+	return assignment(c, target, value)
+}
 
+func (p *parser) callonAssignment9() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAssignment9(stack["target"], stack["value"])
+}
+
+func (c *current) onExpressionStatement2(e any) (any, error) {
 	if e == nil {
 		return nil, nil // errors are reported earlier. just return.
 	}
-	return &ast.ExpressionStatement{Expression: e.(ast.Expression)}, nil
+	return &ast.ExpressionStatement{Span: spanOf(c), Expression: e.(ast.Expression)}, nil
 }
 
 func (p *parser) callonExpressionStatement2() (any, error) {
@@ -3889,7 +4842,6 @@ func (p *parser) callonExpressionStatement2() (any, error) {
 }
 
 func (c *current) onExpressionStatement7() (any, error) {
-
 	return nil, c.throw("expected semicolon")
 }
 
@@ -3900,15 +4852,15 @@ func (p *parser) callonExpressionStatement7() (any, error) {
 }
 
 func (c *current) onForStatement2(init, cond, inc, b any) (any, error) {
-
+	if init == nil || b == nil {
+		return nil, nil // errors are reported earlier. just return.
+	}
 	stmt := &ast.ForStatement{
+		Span: spanOf(c),
 		Body: b.(ast.Statement),
 	}
-	switch initializer := init.(type) {
-	case *ast.VarDeclaration:
-		stmt.VarInitializer = initializer
-	case ast.Expression:
-		stmt.ExpressionInitializer = initializer
+	if initializer, ok := init.(ast.ForInitializer); ok {
+		stmt.Initializer = initializer
 	}
 	if cond != nil {
 		stmt.Condition = cond.(ast.Expression)
@@ -3926,7 +4878,6 @@ func (p *parser) callonForStatement2() (any, error) {
 }
 
 func (c *current) onForStatement21() (any, error) {
-
 	return nil, c.throw("expected statement")
 }
 
@@ -3937,7 +4888,6 @@ func (p *parser) callonForStatement21() (any, error) {
 }
 
 func (c *current) onForStatement35() (any, error) {
-
 	return nil, c.throw("expected right parenthesis")
 }
 
@@ -3948,7 +4898,6 @@ func (p *parser) callonForStatement35() (any, error) {
 }
 
 func (c *current) onForStatement48() (any, error) {
-
 	return nil, c.throw("expected semicolon")
 }
 
@@ -3959,7 +4908,6 @@ func (p *parser) callonForStatement48() (any, error) {
 }
 
 func (c *current) onForStatement58() (any, error) {
-
 	return nil, c.throw("expected var declaration, expression or semicolon")
 }
 
@@ -3970,7 +4918,6 @@ func (p *parser) callonForStatement58() (any, error) {
 }
 
 func (c *current) onForStatement62() (any, error) {
-
 	return nil, c.throw("expected left parenthesis")
 }
 
@@ -3981,8 +4928,11 @@ func (p *parser) callonForStatement62() (any, error) {
 }
 
 func (c *current) onIfStatement2(cond, then, otherwise any) (any, error) {
-
+	if then == nil || otherwise != nil && (otherwise.([]any))[1] == nil {
+		return nil, nil // errors are reported earlier. just return.
+	}
 	stmt := &ast.IfStatement{
+		Span:      spanOf(c),
 		Condition: cond.(ast.Expression),
 		Then:      then.(ast.Statement),
 	}
@@ -3999,7 +4949,6 @@ func (p *parser) callonIfStatement2() (any, error) {
 }
 
 func (c *current) onIfStatement16() (any, error) {
-
 	return nil, c.throw("expected statement of else branch")
 }
 
@@ -4010,7 +4959,6 @@ func (p *parser) callonIfStatement16() (any, error) {
 }
 
 func (c *current) onIfStatement24() (any, error) {
-
 	return nil, c.throw("expected statement")
 }
 
@@ -4021,7 +4969,6 @@ func (p *parser) callonIfStatement24() (any, error) {
 }
 
 func (c *current) onIfStatement30() (any, error) {
-
 	return nil, c.throw("expected right parenthesis")
 }
 
@@ -4032,7 +4979,6 @@ func (p *parser) callonIfStatement30() (any, error) {
 }
 
 func (c *current) onIfStatement35() (any, error) {
-
 	return nil, c.throw("expected if condition")
 }
 
@@ -4043,7 +4989,6 @@ func (p *parser) callonIfStatement35() (any, error) {
 }
 
 func (c *current) onIfStatement39() (any, error) {
-
 	return nil, c.throw("expected left parenthesis")
 }
 
@@ -4054,8 +4999,8 @@ func (p *parser) callonIfStatement39() (any, error) {
 }
 
 func (c *current) onPrintStatement2(e any) (any, error) {
-
 	return &ast.PrintStatement{
+		Span:       spanOf(c),
 		Expression: e.(ast.Expression),
 	}, nil
 }
@@ -4067,7 +5012,6 @@ func (p *parser) callonPrintStatement2() (any, error) {
 }
 
 func (c *current) onPrintStatement8() (any, error) {
-
 	return nil, c.throw("expected semicolon")
 }
 
@@ -4078,7 +5022,6 @@ func (p *parser) callonPrintStatement8() (any, error) {
 }
 
 func (c *current) onPrintStatement12() (any, error) {
-
 	return nil, c.throw("expected expression")
 }
 
@@ -4089,8 +5032,7 @@ func (p *parser) callonPrintStatement12() (any, error) {
 }
 
 func (c *current) onReturnStatement2(e any) (any, error) {
-
-	stmt := new(ast.ReturnStatement)
+	stmt := &ast.ReturnStatement{Span: spanOf(c)}
 	if e != nil {
 		stmt.Expression = e.(ast.Expression)
 	}
@@ -4104,7 +5046,6 @@ func (p *parser) callonReturnStatement2() (any, error) {
 }
 
 func (c *current) onReturnStatement9() (any, error) {
-
 	return nil, c.throw("expected semicolon")
 }
 
@@ -4115,8 +5056,11 @@ func (p *parser) callonReturnStatement9() (any, error) {
 }
 
 func (c *current) onWhileStatement2(cond, b any) (any, error) {
-
+	if b == nil {
+		return nil, nil // errors are reported earlier. just return.
+	}
 	return &ast.WhileStatement{
+		Span:      spanOf(c),
 		Condition: cond.(ast.Expression),
 		Body:      b.(ast.Statement),
 	}, nil
@@ -4129,7 +5073,6 @@ func (p *parser) callonWhileStatement2() (any, error) {
 }
 
 func (c *current) onWhileStatement11() (any, error) {
-
 	return nil, c.throw("expected while body statement")
 }
 
@@ -4140,7 +5083,6 @@ func (p *parser) callonWhileStatement11() (any, error) {
 }
 
 func (c *current) onWhileStatement17() (any, error) {
-
 	return nil, c.throw("expected right parenthesis")
 }
 
@@ -4151,7 +5093,6 @@ func (p *parser) callonWhileStatement17() (any, error) {
 }
 
 func (c *current) onWhileStatement22() (any, error) {
-
 	return nil, c.throw("expected while condition")
 }
 
@@ -4162,7 +5103,6 @@ func (p *parser) callonWhileStatement22() (any, error) {
 }
 
 func (c *current) onWhileStatement26() (any, error) {
-
 	return nil, c.throw("expected left parenthesis")
 }
 
@@ -4173,15 +5113,7 @@ func (p *parser) callonWhileStatement26() (any, error) {
 }
 
 func (c *current) onBlock2(d any) (any, error) {
-
-	var decls []ast.Declaration
-	for _, decl := range d.([]any) {
-		if decl == nil {
-			return nil, nil // errors are reported earlier. just return.
-		}
-		decls = append(decls, decl.(ast.Declaration))
-	}
-	return &ast.BlockStatement{Declarations: decls}, nil
+	return &ast.BlockStatement{Span: spanOf(c), Declarations: declarationsOf(d)}, nil
 }
 
 func (p *parser) callonBlock2() (any, error) {
@@ -4190,23 +5122,72 @@ func (p *parser) callonBlock2() (any, error) {
 	return p.cur.onBlock2(stack["d"])
 }
 
-func (c *current) onBlock9() (any, error) {
-
+func (c *current) onBlock11() (any, error) {
 	return nil, c.throw("expected closing right brace of block")
 }
 
-func (p *parser) callonBlock9() (any, error) {
+func (p *parser) callonBlock11() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onBlock9()
+	return p.cur.onBlock11()
 }
 
-func (c *current) onStatementDeclaration1(s any) (any, error) {
+func (c *current) onDeclaration6(d any) (bool, error) {
+	return d != nil, nil
+}
 
+func (p *parser) callonDeclaration6() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDeclaration6(stack["d"])
+}
+
+func (c *current) onDeclaration2(d any) (any, error) {
+	return d, nil
+}
+
+func (p *parser) callonDeclaration2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDeclaration2(stack["d"])
+}
+
+func (c *current) onDeclaration7() (any, error) {
+	return nil, nil
+}
+
+func (p *parser) callonDeclaration7() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDeclaration7()
+}
+
+func (c *current) onDeclaration16() (any, error) {
+	return nil, nil
+}
+
+func (p *parser) callonDeclaration16() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDeclaration16()
+}
+
+func (c *current) onDeclaration20() (any, error) {
+	return nil, c.unexpected("declaration or statement")
+}
+
+func (p *parser) callonDeclaration20() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDeclaration20()
+}
+
+func (c *current) onStatementDeclaration1(s any) (any, error) {
 	if s == nil {
 		return nil, nil // errors are reported earlier. just return.
 	}
-	return &ast.StatementDeclaration{Statement: s.(ast.Statement)}, nil
+	stmt := s.(ast.Statement)
+	return &ast.StatementDeclaration{Span: stmt.Location(), Statement: stmt}, nil
 }
 
 func (p *parser) callonStatementDeclaration1() (any, error) {
@@ -4216,12 +5197,14 @@ func (p *parser) callonStatementDeclaration1() (any, error) {
 }
 
 func (c *current) onClassDeclaration2(i, ext, m any) (any, error) {
-
-	var methods []ast.FunDeclaration
+	var methods []*ast.FunDeclaration
 	for _, method := range m.([]any) {
-		methods = append(methods, method.(ast.FunDeclaration))
+		if method != nil { // methods with syntax errors are reported already.
+			methods = append(methods, method.(*ast.FunDeclaration))
+		}
 	}
 	decl := &ast.ClassDeclaration{
+		Span:    spanOf(c),
 		Name:    i.(ast.Identifier),
 		Methods: methods,
 	}
@@ -4239,7 +5222,6 @@ func (p *parser) callonClassDeclaration2() (any, error) {
 }
 
 func (c *current) onClassDeclaration17() (any, error) {
-
 	return nil, c.throw("expected closing right brace of class")
 }
 
@@ -4250,7 +5232,6 @@ func (p *parser) callonClassDeclaration17() (any, error) {
 }
 
 func (c *current) onClassDeclaration26() (any, error) {
-
 	return nil, c.throw("expected opening left brace of class")
 }
 
@@ -4261,7 +5242,6 @@ func (p *parser) callonClassDeclaration26() (any, error) {
 }
 
 func (c *current) onClassDeclaration32() (any, error) {
-
 	return nil, c.throw("expected baseclass name")
 }
 
@@ -4272,7 +5252,6 @@ func (p *parser) callonClassDeclaration32() (any, error) {
 }
 
 func (c *current) onClassDeclaration37() (any, error) {
-
 	return nil, c.throw("expected closing right brace of class")
 }
 
@@ -4283,7 +5262,6 @@ func (p *parser) callonClassDeclaration37() (any, error) {
 }
 
 func (c *current) onClassDeclaration44() (any, error) {
-
 	return nil, c.throw("expected opening left brace of class")
 }
 
@@ -4294,7 +5272,6 @@ func (p *parser) callonClassDeclaration44() (any, error) {
 }
 
 func (c *current) onClassDeclaration48() (any, error) {
-
 	return nil, c.throw("expected class name")
 }
 
@@ -4304,19 +5281,74 @@ func (p *parser) callonClassDeclaration48() (any, error) {
 	return p.cur.onClassDeclaration48()
 }
 
-func (c *current) onFunDeclaration1(f any) (any, error) {
+func (c *current) onFunDeclaration2(f any) (any, error) {
+	if f == nil {
+		return nil, nil // errors are reported earlier. just return.
+	}
+	decl := f.(*ast.FunDeclaration)
+	decl.Span = spanOf(c)
+	return decl, nil
+}
+
+func (p *parser) callonFunDeclaration2() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFunDeclaration2(stack["f"])
+}
+
+func (c *current) onFunDeclaration7() (any, error) {
+	return nil, c.throw("expected function name")
+}
+
+func (p *parser) callonFunDeclaration7() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFunDeclaration7()
+}
+
+func (c *current) onMethod6(f any) (bool, error) {
+	return f != nil, nil
+}
+
+func (p *parser) callonMethod6() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onMethod6(stack["f"])
+}
+
+func (c *current) onMethod2(f any) (any, error) {
 	return f, nil
 }
 
-func (p *parser) callonFunDeclaration1() (any, error) {
+func (p *parser) callonMethod2() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onFunDeclaration1(stack["f"])
+	return p.cur.onMethod2(stack["f"])
 }
 
-func (c *current) onVarDeclaration2(i, init any) (any, error) {
+func (c *current) onMethod7() (any, error) {
+	return nil, nil // errors are reported earlier. just return.
+}
+
+func (p *parser) callonMethod7() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onMethod7()
+}
 
+func (c *current) onMethod11() (any, error) {
+	return nil, c.unexpected("method name")
+}
+
+func (p *parser) callonMethod11() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onMethod11()
+}
+
+func (c *current) onVarDeclaration2(i, init any) (any, error) {
 	decl := &ast.VarDeclaration{
+		Span: spanOf(c),
 		Name: i.(ast.Identifier),
 	}
 	if init != nil {
@@ -4332,7 +5364,6 @@ func (p *parser) callonVarDeclaration2() (any, error) {
 }
 
 func (c *current) onVarDeclaration13() (any, error) {
-
 	return nil, c.throw("expected semicolon")
 }
 
@@ -4343,7 +5374,6 @@ func (p *parser) callonVarDeclaration13() (any, error) {
 }
 
 func (c *current) onVarDeclaration19() (any, error) {
-
 	return nil, c.throw("expected expression")
 }
 
@@ -4354,7 +5384,6 @@ func (p *parser) callonVarDeclaration19() (any, error) {
 }
 
 func (c *current) onVarDeclaration24() (any, error) {
-
 	return nil, c.throw("expected semicolon")
 }
 
@@ -4365,7 +5394,6 @@ func (p *parser) callonVarDeclaration24() (any, error) {
 }
 
 func (c *current) onVarDeclaration28() (any, error) {
-
 	return nil, c.throw("expected variable name")
 }
 
@@ -4375,16 +5403,28 @@ func (p *parser) callonVarDeclaration28() (any, error) {
 	return p.cur.onVarDeclaration28()
 }
 
-func (c *current) onProgram1(d any) (any, error) {
+func (c *current) onStraySemicolon1() (any, error) {
+	return nil, c.unexpected("")
+}
 
-	var decls []ast.Declaration
-	for _, decl := range d.([]any) {
-		if decl == nil {
-			return nil, nil // errors are reported earlier. just return.
-		}
-		decls = append(decls, decl.(ast.Declaration))
-	}
-	return decls, nil
+func (p *parser) callonStraySemicolon1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStraySemicolon1()
+}
+
+func (c *current) onStrayToken3() (any, error) {
+	return nil, c.unexpected("")
+}
+
+func (p *parser) callonStrayToken3() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStrayToken3()
+}
+
+func (c *current) onProgram1(d any) (any, error) {
+	return declarationsOf(d), nil
 }
 
 func (p *parser) callonProgram1() (any, error) {
@@ -4393,6 +5433,16 @@ func (p *parser) callonProgram1() (any, error) {
 	return p.cur.onProgram1(stack["d"])
 }
 
+func (c *current) onEOF6() (any, error) {
+	return nil, c.unexpected("end of input")
+}
+
+func (p *parser) callonEOF6() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onEOF6()
+}
+
 var (
 	// errNoRule is returned when the grammar to parse has no rule.
 	errNoRule = errors.New("grammar has no rule")
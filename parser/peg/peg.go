@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/mussel-lox/clam/ast"
 	"github.com/mussel-lox/clam/internal/diagnostic"
+	"github.com/mussel-lox/clam/parser/internal/literal"
+	"github.com/mussel-lox/clam/parser/internal/syntax"
 )
 
 const (
@@ -69,33 +72,68 @@ var operatorMapping = map[TokenKind]ast.BinaryOperator{
 type TokenKind int
 
 // ParseWithDiagnostic turns internal parserError into Diagnostic, which is more friendly to read.
+//
+// The parser recovers from syntax errors, so all of them are reported at once as a [diagnostic.List]. The declarations
+// without errors are still returned as a partial syntax tree along with the error.
 func ParseWithDiagnostic(filename, source string) ([]ast.Declaration, error) {
-	var builder strings.Builder
-
 	src := diagnostic.NewSource(filename, source)
-	program, err := ParseReader(filename, strings.NewReader(source), Entrypoint("Program"))
+	result, err := ParseReader(filename, strings.NewReader(source), Entrypoint("Program"), Memoize(true))
+	program, _ := result.([]ast.Declaration)
 	if err != nil {
 		var errorList errList
 		if !errors.As(err, &errorList) {
-			panic("unreachable case in asserting errList")
+			errorList = errList{err}
 		}
+		var diagnostics diagnostic.List
+		truncated := false
 		for _, err := range errorList {
-			var parserErr *parserError
-			if !errors.As(err, &parserErr) {
-				panic("unreachable case in asserting *parserError")
-			}
-			var locatedErr locatedError
-			if !errors.As(parserErr.Inner, &locatedErr) {
-				panic("unreachable case in asserting locatedError")
+			d := diagnosticOf(err)
+			// Nothing is reported at the end of a source truncated by lexical errors, which are the cause. Errors may
+			// point before the trivia at the end, like an unterminated comment.
+			if span, _ := d.Span(); truncated && span.Start+skipTrivia(source[span.Start:]) == len(source) {
+				continue
 			}
-			diag := diagnostic.NewDiagnostic(fmt.Sprint(locatedErr.Error())).
-				At(locatedErr.line-1, locatedErr.column-1).
-				Attach(src)
-			_, _ = fmt.Fprintln(&builder, diag)
+			truncated = truncated || truncates(err)
+			diagnostics.Add(d.Attach(src))
 		}
-		return nil, errors.New(builder.String())
+		return program, diagnostics
+	}
+	return program, nil
+}
+
+// diagnosticOf converts an error reported by the generated parser into a Diagnostic. Besides the located errors thrown
+// by grammar actions, pigeon reports its own errors (like no match or invalid encoding), and the panics recovered from
+// actions, at the position where parsing stopped.
+func diagnosticOf(err error) *diagnostic.Diagnostic {
+	var parserErr *parserError
+	if !errors.As(err, &parserErr) {
+		return diagnostic.NewDiagnostic(err.Error()).WithCode(syntax.CodeInternal)
+	}
+
+	var locatedErr locatedError
+	if errors.As(parserErr.Inner, &locatedErr) {
+		return diagnostic.NewDiagnostic(locatedErr.Error()).
+			WithCode(locatedErr.code).
+			AtSpan(diagnostic.Span{Start: locatedErr.offset, End: locatedErr.end})
+	}
+
+	var code, message string
+	switch {
+	case errors.Is(parserErr.Inner, errInvalidEncoding):
+		code, message = syntax.CodeLexical, "invalid UTF-8 encoding"
+	case len(parserErr.expected) > 0:
+		code, message = syntax.CodeSyntax, "unexpected input, expected "+listJoin(parserErr.expected, ", ", "or")
+	default:
+		code, message = syntax.CodeInternal, "internal parser error: "+parserErr.Inner.Error()
 	}
-	return program.([]ast.Declaration), nil
+	return diagnostic.NewDiagnostic(message).WithCode(code).AtOffset(parserErr.pos.offset)
+}
+
+// truncates tells whether err is about an unterminated string or comment, which consumes the rest of the source.
+func truncates(err error) bool {
+	var parserErr *parserError
+	var locatedErr locatedError
+	return errors.As(err, &parserErr) && errors.As(parserErr.Inner, &locatedErr) && locatedErr.truncates
 }
 
 func parseBinary(l, pat any) ast.Expression {
@@ -109,6 +147,7 @@ func parseBinary(l, pat any) ast.Expression {
 		right := pattern[1].(ast.Expression)
 
 		left = &ast.BinaryExpression{
+			Span:     left.Location().To(right.Location()),
 			Left:     left,
 			Operator: operator,
 			Right:    right,
@@ -117,21 +156,225 @@ func parseBinary(l, pat any) ast.Expression {
 	return left
 }
 
+// declarationsOf collects the declarations matched by a repetition of the Declaration rule. Declarations with syntax
+// errors are nil and left out, so a program with errors still has a partial syntax tree.
+func declarationsOf(d any) []ast.Declaration {
+	var decls []ast.Declaration
+	for _, decl := range d.([]any) {
+		if decl != nil {
+			decls = append(decls, decl.(ast.Declaration))
+		}
+	}
+	return decls
+}
+
+// assignment creates the assignment of value to target matched by the current rule. A target rejected by
+// [syntax.IsAssignable] is reported at its span, along with the assignment for a partial syntax tree.
+func assignment(c *current, target, value any) (any, error) {
+	expr := &ast.AssignmentExpression{
+		Span:   spanOf(c),
+		Target: target.(ast.Expression),
+		Value:  value.(ast.Expression),
+	}
+	if !syntax.IsAssignable(expr.Start, expr.Target) {
+		return expr, newLocatedErrorOver(c, expr.Target.Location(), syntax.CodeInvalidAssignmentTarget, syntax.InvalidAssignmentTarget)
+	}
+	return expr, nil
+}
+
+// invocation is the parenthesized argument list of a call, remembering where it ends.
+type invocation struct {
+	arguments []ast.Expression
+	end       int
+}
+
+// spanOf returns the span of the text matched by the current rule, without the leading whitespaces and comments.
+// Tokens never consume trailing whitespaces, so the text matched by any rule ends with a token.
+func spanOf(c *current) ast.Span {
+	text := string(c.text)
+	start := c.pos.offset + skipTrivia(text)
+	end := c.pos.offset + len(strings.TrimRightFunc(text, unicode.IsSpace))
+	return ast.Span{Start: start, End: max(start, end)}
+}
+
+// skipTrivia returns the length of the leading whitespaces and comments of text, matching the "WHITESPACES" rule.
+func skipTrivia(text string) int {
+	offset := 0
+	for offset < len(text) {
+		switch {
+		case strings.ContainsRune(" \t\r\n", rune(text[offset])):
+			offset++
+		case strings.HasPrefix(text[offset:], "//"):
+			end := strings.IndexByte(text[offset:], '\n')
+			if end < 0 {
+				return len(text)
+			}
+			offset += end
+		case strings.HasPrefix(text[offset:], "/*"):
+			offset += blockCommentLength(text[offset:])
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// blockCommentLength returns the length of the (possibly nested) block comment at the beginning of text. An
+// unterminated block comment extends to the end of text.
+func blockCommentLength(text string) int {
+	depth := 0
+	for offset := 0; offset < len(text); {
+		switch {
+		case strings.HasPrefix(text[offset:], "/*"):
+			depth++
+			offset += 2
+		case strings.HasPrefix(text[offset:], "*/"):
+			depth--
+			offset += 2
+			if depth == 0 {
+				return offset
+			}
+		default:
+			offset++
+		}
+	}
+	return len(text)
+}
+
+// numberLiteral converts the number literal matched by the current rule. Like stringLiteral, a literal is still
+// returned along with the error.
+func numberLiteral(c *current) (any, error) {
+	start := skipTrivia(string(c.text))
+	value, err := literal.ParseNumber(matchedTextOf(c))
+	if err != nil {
+		return ast.NumberLiteral{Span: spanOf(c)}, newLocatedErrorAt(c, start, syntax.CodeLexical, err.Error())
+	}
+	return ast.NumberLiteral{Span: spanOf(c), Value: value}, nil
+}
+
+// stringLiteral decodes the string literal matched by the current rule. An empty literal is still returned along with
+// the error of an invalid escape sequence, so that the rules using it can go on.
+func stringLiteral(c *current) (any, error) {
+	start := skipTrivia(string(c.text))
+	value, index, err := literal.Unquote(string(c.text[start:]))
+	if err != nil {
+		return ast.StringLiteral{Span: spanOf(c)}, newLocatedErrorAt(c, start+index, syntax.CodeLexical, err.Error())
+	}
+	return ast.StringLiteral{Span: spanOf(c), Value: value}, nil
+}
+
+// locatedError is an error thrown by grammar actions at a range of byte offsets in the source code. Most of them point
+// at a single offset, where end equals offset. The code is one of those in package syntax.
 type locatedError struct {
-	line    int
-	column  int
+	offset  int
+	end     int
+	code    string
 	message string
+	// truncates is set by unterminated tokens, which consume the rest of the source.
+	truncates bool
 }
 
+// newLocatedError creates a syntax error pointing at the end of the text matched by the current rule, which is where
+// something is missing.
 func newLocatedError(c *current, message string) locatedError {
 	text := strings.TrimRightFunc(string(c.text), unicode.IsSpace)
+	return newLocatedErrorAt(c, len(text), syntax.CodeSyntax, message)
+}
+
+// newLocatedErrorAt creates a locatedError pointing at index of the text matched by the current rule.
+func newLocatedErrorAt(c *current, index int, code, message string) locatedError {
+	offset := c.pos.offset + index
+	return locatedError{
+		offset:  offset,
+		end:     offset,
+		code:    code,
+		message: message,
+	}
+}
+
+// newUnterminatedError creates a lexical locatedError pointing at index of the text matched by the current rule, which
+// is the start of a token running to the end of the source.
+func newUnterminatedError(c *current, index int, message string) locatedError {
+	err := newLocatedErrorAt(c, index, syntax.CodeLexical, message)
+	err.truncates = true
+	return err
+}
+
+// newLocatedErrorOver creates a locatedError covering span.
+func newLocatedErrorOver(_ *current, span ast.Span, code, message string) locatedError {
+	return locatedError{
+		offset:  span.Start,
+		end:     span.End,
+		code:    code,
+		message: message,
+	}
+}
+
+// newUnexpectedError creates a locatedError covering the first token of the text matched by the current rule, which is
+// not expected by the grammar. The message names the token, or the character if it starts no token at all, followed
+// by what is expected instead if expected is not empty.
+func newUnexpectedError(c *current, expected string) locatedError {
+	text := string(c.text)
+	start := skipTrivia(text)
+	length, description := describeToken(text[start:])
+	message := "unexpected " + description
+	if expected != "" {
+		message += ", expected " + expected
+	}
 	return locatedError{
-		line:    c.pos.line,
-		column:  c.pos.col + len(text),
+		offset:  c.pos.offset + start,
+		end:     c.pos.offset + start + length,
+		code:    syntax.CodeSyntax,
 		message: message,
 	}
 }
 
+// reservedWords are the words matched by RESERVED_WORD in the grammar.
+var reservedWords = map[string]bool{
+	"and": true, "class": true, "else": true, "false": true, "for": true, "fun": true, "if": true, "nil": true,
+	"or": true, "print": true, "return": true, "super": true, "this": true, "true": true, "var": true, "while": true,
+}
+
+// describeToken returns the length and the description of the token at the beginning of text.
+func describeToken(text string) (int, string) {
+	isWord := func(r rune) bool { return r == '_' || r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) }
+	r, size := utf8.DecodeRuneInString(text)
+	switch {
+	case size == 0:
+		return 0, "end of input"
+	case r == '"':
+		end := strings.IndexByte(text[1:], '"')
+		if end < 0 {
+			return len(text), "string literal"
+		}
+		return end + 2, "string literal"
+	case r == '_' || r < utf8.RuneSelf && unicode.IsLetter(r):
+		end := strings.IndexFunc(text, func(r rune) bool { return !isWord(r) })
+		if end < 0 {
+			end = len(text)
+		}
+		if reservedWords[text[:end]] {
+			return end, fmt.Sprintf("keyword %q", text[:end])
+		}
+		return end, fmt.Sprintf("identifier %q", text[:end])
+	case r < utf8.RuneSelf && unicode.IsDigit(r):
+		end := strings.IndexFunc(text, func(r rune) bool { return !isWord(r) && r != '.' })
+		if end < 0 {
+			end = len(text)
+		}
+		return end, fmt.Sprintf("number %s", text[:end])
+	}
+	for _, operator := range []string{"!=", "==", "<=", ">="} {
+		if strings.HasPrefix(text, operator) {
+			return len(operator), fmt.Sprintf("token %q", operator)
+		}
+	}
+	if strings.ContainsRune("(){},.-+;/*!=<>", r) {
+		return size, fmt.Sprintf("token %q", string(r))
+	}
+	return size, fmt.Sprintf("character %q", r)
+}
+
 func (l locatedError) Error() string {
 	return l.message
 }
@@ -0,0 +1,184 @@
+package pratt
+
+import (
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/parser/internal/literal"
+	"github.com/mussel-lox/clam/parser/internal/syntax"
+)
+
+const (
+	precedenceNone precedence = iota
+	precedenceAssignment
+	precedenceOr
+	precedenceAnd
+	precedenceEquality
+	precedenceComparison
+	precedenceTerm
+	precedenceFactor
+	precedenceUnary
+)
+
+// precedence is the binding power of operators. Operators of higher precedence bind tighter.
+type precedence byte
+
+// infix is the binary operator of a token, which has no precedence if the token is not a binary operator.
+type infix struct {
+	operator   ast.BinaryOperator
+	precedence precedence
+}
+
+var infixes = [While + 1]infix{
+	Or:           {ast.BinopLogicalOr, precedenceOr},
+	And:          {ast.BinopLogicalAnd, precedenceAnd},
+	EqualEqual:   {ast.BinopEqual, precedenceEquality},
+	BangEqual:    {ast.BinopNotEqual, precedenceEquality},
+	Greater:      {ast.BinopGreater, precedenceComparison},
+	GreaterEqual: {ast.BinopGreaterEqual, precedenceComparison},
+	Less:         {ast.BinopLess, precedenceComparison},
+	LessEqual:    {ast.BinopLessEqual, precedenceComparison},
+	Plus:         {ast.BinopAdd, precedenceTerm},
+	Minus:        {ast.BinopSubtract, precedenceTerm},
+	Star:         {ast.BinopMultiply, precedenceFactor},
+	Slash:        {ast.BinopDivide, precedenceFactor},
+}
+
+func (p *parser) expression() ast.Expression {
+	return p.parsePrecedence(precedenceAssignment)
+}
+
+// parsePrecedence parses an expression whose operators bind at least as tight as minimum. Binary operators are left
+// associative, while assignments are right associative.
+func (p *parser) parsePrecedence(minimum precedence) ast.Expression {
+	start := p.token.Start
+	var left ast.Expression
+	if p.token.Kind == Bang || p.token.Kind == Minus {
+		left = p.unary()
+	} else {
+		left = p.call()
+	}
+
+	for {
+		if p.token.Kind == Equal && minimum <= precedenceAssignment {
+			p.advance()
+			value := p.parsePrecedence(precedenceAssignment)
+			return p.assignment(start, left, value)
+		}
+		infix := infixes[p.token.Kind]
+		if infix.precedence == precedenceNone || infix.precedence < minimum {
+			return left
+		}
+		p.advance()
+		right := p.parsePrecedence(infix.precedence + 1)
+		left = &ast.BinaryExpression{
+			Span:     left.Location().To(right.Location()),
+			Left:     left,
+			Operator: infix.operator,
+			Right:    right,
+		}
+	}
+}
+
+func (p *parser) unary() ast.Expression {
+	token := p.advance()
+	operator := ast.UopNegate
+	if token.Kind == Bang {
+		operator = ast.UopLogicalNot
+	}
+	operand := p.parsePrecedence(precedenceUnary)
+	return &ast.UnaryExpression{Span: p.span(token.Start), Operator: operator, Operand: operand}
+}
+
+// assignment builds the assignment starting at start. If [syntax.IsAssignable] rejects the target, the error covers the
+// target and parsing goes on without bailing out.
+func (p *parser) assignment(start int, target, value ast.Expression) ast.Expression {
+	if !syntax.IsAssignable(start, target) {
+		span := target.Location()
+		p.reportSpan(span.Start, span.End, syntax.CodeInvalidAssignmentTarget, syntax.InvalidAssignmentTarget)
+	}
+	return &ast.AssignmentExpression{Span: p.span(start), Target: target, Value: value}
+}
+
+// call parses a primary expression followed by invocations and property accesses, spanned as package syntax describes.
+func (p *parser) call() ast.Expression {
+	start := p.token.Start
+	expr := p.primary()
+	for {
+		switch p.token.Kind {
+		case LeftParenthesis:
+			p.advance()
+			invocation := &ast.InvocationExpression{Callee: expr}
+			if p.token.Kind != RightParenthesis {
+				invocation.Arguments = append(invocation.Arguments, p.expression())
+				for p.match(Comma) {
+					invocation.Arguments = append(invocation.Arguments, p.expression())
+				}
+			}
+			p.expect(RightParenthesis, "right parenthesis after arguments")
+			invocation.Span = p.span(start)
+			expr = invocation
+		case Dot:
+			p.advance()
+			property := p.identifier("property name after dot")
+			expr = &ast.PropertyAccessExpression{
+				Span:     ast.Span{Start: start, End: property.End},
+				Target:   expr,
+				Property: property,
+			}
+		default:
+			return expr
+		}
+	}
+}
+
+func (p *parser) primary() ast.Expression {
+	token := p.token
+	span := ast.Span{Start: token.Start, End: token.End}
+	switch token.Kind {
+	case True, False:
+		p.advance()
+		return ast.BooleanLiteral{Span: span, Value: token.Kind == True}
+	case Nil:
+		p.advance()
+		return ast.Nil{Span: span}
+	case This:
+		p.advance()
+		return ast.This{Span: span}
+	case Number:
+		p.advance()
+		value, err := literal.ParseNumber(p.source[token.Start:token.End])
+		if err != nil {
+			p.reportSpan(token.Start, token.Start, syntax.CodeLexical, err.Error())
+		}
+		return ast.NumberLiteral{Span: span, Value: value}
+	case String:
+		p.advance()
+		value, index, err := literal.Unquote(p.source[token.Start:token.End])
+		if err != nil {
+			p.reportSpan(token.Start+index, token.Start+index, syntax.CodeLexical, err.Error())
+		}
+		return ast.StringLiteral{Span: span, Value: value}
+	case UnterminatedString:
+		p.advance()
+		return ast.StringLiteral{Span: span}
+	case Identifier:
+		identifier := p.identifier("")
+		return &identifier
+	case LeftParenthesis:
+		p.advance()
+		expr := p.expression()
+		p.expect(RightParenthesis, "right parenthesis after expression")
+		return expr
+	case Super:
+		p.advance()
+		p.expect(Dot, "dot after super")
+		property := p.identifier("method name of super")
+		return &ast.PropertyAccessExpression{
+			Span:     p.span(token.Start),
+			Target:   ast.Super{Span: span},
+			Property: property,
+		}
+	default:
+		p.fail("expression")
+		panic("unreachable")
+	}
+}
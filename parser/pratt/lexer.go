@@ -0,0 +1,200 @@
+package pratt
+
+import "unicode/utf8"
+
+// lexer splits the source code into tokens on demand, dropping the whitespaces and comments between them. Lexical
+// errors are reported through report without stopping.
+type lexer struct {
+	source string
+	offset int
+	report func(start, end int, message string)
+	// truncated tells whether an unterminated string or comment has consumed the rest of the source.
+	truncated bool
+}
+
+// next scans the token after the current offset. The end of input is an [EOF] token, which is returned repeatedly.
+func (l *lexer) next() Token {
+	l.skipTrivia()
+	start := l.offset
+	if start >= len(l.source) {
+		return Token{Kind: EOF, Start: start, End: start}
+	}
+
+	c := l.source[start]
+	l.offset++
+	kind := Illegal
+	switch {
+	case isAlpha(c):
+		for l.offset < len(l.source) && (isAlpha(l.source[l.offset]) || isDigit(l.source[l.offset])) {
+			l.offset++
+		}
+		kind = Identifier
+		if keyword, exists := keywords[l.source[start:l.offset]]; exists {
+			kind = keyword
+		}
+	case isDigit(c):
+		l.skipDigits()
+		if l.peek(0) == '.' && isDigit(l.peek(1)) {
+			l.offset++
+			l.skipDigits()
+		}
+		kind = Number
+	case c == '"':
+		kind = l.scanString(start)
+	case c == '(':
+		kind = LeftParenthesis
+	case c == ')':
+		kind = RightParenthesis
+	case c == '{':
+		kind = LeftBrace
+	case c == '}':
+		kind = RightBrace
+	case c == ',':
+		kind = Comma
+	case c == '.':
+		kind = Dot
+	case c == '-':
+		kind = Minus
+	case c == '+':
+		kind = Plus
+	case c == ';':
+		kind = Semicolon
+	case c == '/':
+		kind = Slash
+	case c == '*':
+		kind = Star
+	case c == '!':
+		kind = l.either('=', BangEqual, Bang)
+	case c == '=':
+		kind = l.either('=', EqualEqual, Equal)
+	case c == '>':
+		kind = l.either('=', GreaterEqual, Greater)
+	case c == '<':
+		kind = l.either('=', LessEqual, Less)
+	default:
+		r, size := utf8.DecodeRuneInString(l.source[start:])
+		l.offset = start + size
+		if r == utf8.RuneError && size == 1 {
+			l.report(start, start, "invalid UTF-8 encoding")
+			return l.next()
+		}
+	}
+	return Token{Kind: kind, Start: start, End: l.offset}
+}
+
+// peek returns the byte at offset ahead of the current offset, or 0 at the end of input.
+func (l *lexer) peek(offset int) byte {
+	if l.offset+offset < len(l.source) {
+		return l.source[l.offset+offset]
+	}
+	return 0
+}
+
+// either returns matched and consumes next if the current byte is next, or returns otherwise.
+func (l *lexer) either(next byte, matched, otherwise TokenKind) TokenKind {
+	if l.peek(0) == next {
+		l.offset++
+		return matched
+	}
+	return otherwise
+}
+
+func (l *lexer) skipDigits() {
+	for l.offset < len(l.source) && isDigit(l.source[l.offset]) {
+		l.offset++
+	}
+}
+
+// scanString scans the rest of a string literal starting at start. Strings may span multiple lines, and escape
+// sequences are only skipped here, then decoded by the parser.
+func (l *lexer) scanString(start int) TokenKind {
+	for l.offset < len(l.source) {
+		switch l.source[l.offset] {
+		case '"':
+			l.offset++
+			l.checkEncoding(start)
+			return String
+		case '\\':
+			l.offset++
+			if l.offset < len(l.source) {
+				_, size := utf8.DecodeRuneInString(l.source[l.offset:])
+				l.offset += size
+			}
+		default:
+			l.offset++
+		}
+	}
+	l.checkEncoding(start)
+	l.report(start, start, "unterminated string")
+	l.truncated = true
+	return UnterminatedString
+}
+
+// skipTrivia skips whitespaces, line comments and (possibly nested) block comments.
+func (l *lexer) skipTrivia() {
+	for l.offset < len(l.source) {
+		switch l.source[l.offset] {
+		case ' ', '\t', '\r', '\n':
+			l.offset++
+		case '/':
+			switch l.peek(1) {
+			case '/':
+				start := l.offset
+				for l.offset < len(l.source) && l.source[l.offset] != '\n' {
+					l.offset++
+				}
+				l.checkEncoding(start)
+			case '*':
+				l.skipBlockComment()
+			default:
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) skipBlockComment() {
+	start := l.offset
+	depth := 0
+	for l.offset < len(l.source) {
+		switch {
+		case l.source[l.offset] == '/' && l.peek(1) == '*':
+			depth++
+			l.offset += 2
+		case l.source[l.offset] == '*' && l.peek(1) == '/':
+			depth--
+			l.offset += 2
+			if depth == 0 {
+				l.checkEncoding(start)
+				return
+			}
+		default:
+			l.offset++
+		}
+	}
+	l.checkEncoding(start)
+	l.report(start, start, "unterminated block comment")
+	l.truncated = true
+}
+
+// checkEncoding reports the first invalid UTF-8 sequence between start and the current offset. Other tokens are
+// checked as they are scanned.
+func (l *lexer) checkEncoding(start int) {
+	text := l.source[start:l.offset]
+	if utf8.ValidString(text) {
+		return
+	}
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r == utf8.RuneError && size == 1 {
+			l.report(start+i, start+i, "invalid UTF-8 encoding")
+			return
+		}
+		i += size
+	}
+}
+
+func isAlpha(c byte) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_' }
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
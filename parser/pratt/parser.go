@@ -0,0 +1,393 @@
+// Package pratt contains a hand-written lexer and parser of Lox. Declarations and statements are parsed by recursive
+// descent, and expressions by precedence climbing (Pratt parsing).
+//
+// It produces the same syntax tree as package peg, spans included, while scanning the source code once without
+// backtracking.
+package pratt
+
+import (
+	"strings"
+
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+	"github.com/mussel-lox/clam/parser/internal/syntax"
+)
+
+// parser holds the state of parsing one source code. The current token is looked ahead, and the end of the previous
+// token closes the spans of the nodes.
+type parser struct {
+	lexer
+	filename    string
+	src         *diagnostic.Source
+	token       Token
+	previousEnd int
+	// failedAt is the offset of the last error reported by fail, which is not reported again by the enclosing rules
+	// after bailing out.
+	failedAt    int
+	diagnostics diagnostic.List
+}
+
+// bailout is panicked by the parser on syntax errors after reporting them, and recovered by the innermost declaration.
+// The rest of the declaration is then skipped.
+type bailout struct{}
+
+// Parse parses the whole source code into declarations. Like peg.ParseWithDiagnostic, the parser recovers from syntax
+// errors, and the declarations without errors are returned along with a [diagnostic.List] of all errors.
+func Parse(filename, source string) ([]ast.Declaration, error) {
+	p := &parser{filename: filename, failedAt: -1}
+	p.lexer = lexer{source: source, report: func(start, end int, message string) {
+		p.reportSpan(start, end, syntax.CodeLexical, message)
+	}}
+	p.token = p.next()
+
+	var program []ast.Declaration
+	for p.token.Kind != EOF {
+		if p.token.Kind == Semicolon || p.token.Kind == RightBrace {
+			// A token found by fail is reported already.
+			if p.token.Start != p.failedAt {
+				p.reportToken(p.token, syntax.CodeSyntax, "unexpected "+describe(p.token, source))
+			}
+			p.advance()
+			continue
+		}
+		if decl := p.declaration(); decl != nil {
+			program = append(program, decl)
+		}
+	}
+	return program, p.diagnostics.Err()
+}
+
+// advance moves to the next token, and returns the current one.
+func (p *parser) advance() Token {
+	token := p.token
+	p.previousEnd = token.End
+	p.token = p.next()
+	return token
+}
+
+// match advances if the current token is of kind.
+func (p *parser) match(kind TokenKind) bool {
+	if p.token.Kind != kind {
+		return false
+	}
+	p.advance()
+	return true
+}
+
+// expect advances over a token of kind, or fails with the message "expected what".
+func (p *parser) expect(kind TokenKind, what string) Token {
+	if p.token.Kind != kind {
+		p.fail(what)
+	}
+	return p.advance()
+}
+
+// fail reports that what is expected instead of the current token, and bails out. The error points at the current
+// token, or right after the previous token if the current one is on another line, which is where something is missing.
+// Nothing is reported at the end of a source truncated by lexical errors, which are the cause.
+func (p *parser) fail(what string) {
+	start, end := p.token.Start, p.token.End
+	if strings.ContainsRune(p.source[p.previousEnd:p.token.Start], '\n') {
+		start, end = p.previousEnd, p.previousEnd
+	}
+	if start != p.failedAt && !(p.token.Kind == EOF && p.truncated) {
+		p.reportSpan(start, end, syntax.CodeSyntax, "expected "+what+", found "+describe(p.token, p.source))
+		p.failedAt = start
+	}
+	panic(bailout{})
+}
+
+// unexpected reports that the current token cannot be where it is, instead of what, and bails out.
+func (p *parser) unexpected(what string) {
+	p.reportToken(p.token, syntax.CodeSyntax, "unexpected "+describe(p.token, p.source)+", expected "+what)
+	p.failedAt = p.token.Start
+	panic(bailout{})
+}
+
+func (p *parser) reportToken(t Token, code, message string) {
+	p.reportSpan(t.Start, t.End, code, message)
+}
+
+// reportSpan reports an error without bailing out. The diagnostic source is only built when there are errors.
+func (p *parser) reportSpan(start, end int, code, message string) {
+	if p.src == nil {
+		p.src = diagnostic.NewSource(p.filename, p.source)
+	}
+	d := diagnostic.NewDiagnostic(message).WithCode(code).AtSpan(diagnostic.Span{Start: start, End: end}).Attach(p.src)
+	p.diagnostics.Add(d)
+}
+
+// span returns the span from start to the end of the previous token.
+func (p *parser) span(start int) ast.Span {
+	return ast.Span{Start: start, End: p.previousEnd}
+}
+
+// synchronize skips the rest of a statement after a syntax error. The semicolon ending it is consumed, while a right
+// brace or a keyword starting the next declaration is left as the current token.
+func (p *parser) synchronize() {
+	for p.token.Kind != EOF && !endsStatement(p.token.Kind) {
+		if p.advance().Kind == Semicolon {
+			return
+		}
+	}
+}
+
+// endsStatement tells whether a token of kind ends the statement before it without being part of it, which is the
+// closing brace of the enclosing block or a keyword starting a declaration or statement.
+func endsStatement(kind TokenKind) bool {
+	switch kind {
+	case RightBrace, Class, Fun, Var, For, If, While, Print, Return:
+		return true
+	}
+	return false
+}
+
+// startsDeclaration tells whether a token of kind starts a declaration or a statement, including expression
+// statements. Other tokens are unexpected where a declaration is expected.
+func startsDeclaration(kind TokenKind) bool {
+	switch kind {
+	case Class, Fun, Var, For, If, Print, Return, While, LeftBrace,
+		Identifier, String, UnterminatedString, Number, LeftParenthesis, Minus, Bang, False, Nil, Super, This, True:
+		return true
+	}
+	return false
+}
+
+// declaration parses a declaration, or returns nil if it has syntax errors. The body of a function with a broken
+// header is skipped as a whole, so that its statements and closing brace are not taken for those of the enclosing
+// block.
+func (p *parser) declaration() (decl ast.Declaration) {
+	kind := p.token.Kind
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(bailout); !ok {
+				panic(r)
+			}
+			if kind != Fun || !p.skipBody() {
+				p.synchronize()
+			}
+			decl = nil
+		}
+	}()
+
+	switch kind {
+	case Class:
+		return p.classDeclaration()
+	case Fun:
+		start := p.advance().Start
+		decl := p.function("function")
+		decl.Span = p.span(start)
+		return decl
+	case Var:
+		return p.varDeclaration()
+	default:
+		if !startsDeclaration(kind) {
+			p.unexpected("declaration or statement")
+		}
+		stmt := p.statement()
+		return &ast.StatementDeclaration{Span: stmt.Location(), Statement: stmt}
+	}
+}
+
+func (p *parser) classDeclaration() *ast.ClassDeclaration {
+	start := p.advance().Start
+	decl := &ast.ClassDeclaration{Name: p.identifier("class name")}
+	if p.match(Less) {
+		baseclass := p.identifier("baseclass name")
+		decl.Baseclass = &baseclass
+	}
+	p.expect(LeftBrace, "left brace before class body")
+	for p.token.Kind != RightBrace && p.token.Kind != EOF {
+		if method := p.method(); method != nil {
+			decl.Methods = append(decl.Methods, method)
+		}
+	}
+	p.expect(RightBrace, "right brace after class body")
+	decl.Span = p.span(start)
+	return decl
+}
+
+// method parses a method in a class body, or returns nil if it has syntax errors. The rest of the method is skipped to
+// the end of its body, so that the following methods are still parsed.
+func (p *parser) method() (method *ast.FunDeclaration) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(bailout); !ok {
+				panic(r)
+			}
+			p.skipMethod()
+			method = nil
+		}
+	}()
+	return p.function("method")
+}
+
+// skipBody skips the tokens before the next left brace, and the brace-balanced block starting there. It stops without
+// skipping the block at where synchronize would stop, and reports whether a block is skipped.
+func (p *parser) skipBody() bool {
+	for p.token.Kind != LeftBrace {
+		if p.token.Kind == EOF || p.token.Kind == Semicolon || endsStatement(p.token.Kind) {
+			return false
+		}
+		p.advance()
+	}
+	p.skipMethod()
+	return true
+}
+
+// skipMethod skips tokens to the closing brace of the current method body, or to the closing brace of the class body.
+func (p *parser) skipMethod() {
+	depth := 0
+	for p.token.Kind != EOF {
+		switch p.token.Kind {
+		case LeftBrace:
+			depth++
+		case RightBrace:
+			if depth == 0 {
+				return
+			}
+			depth--
+			if depth == 0 {
+				p.advance()
+				return
+			}
+		}
+		p.advance()
+	}
+}
+
+// function parses the name, parameters and body of a function or method of kind.
+func (p *parser) function(kind string) *ast.FunDeclaration {
+	start := p.token.Start
+	decl := &ast.FunDeclaration{Name: p.identifier(kind + " name")}
+	p.expect(LeftParenthesis, "left parenthesis after "+kind+" name")
+	if p.token.Kind != RightParenthesis {
+		decl.Parameters = append(decl.Parameters, p.identifier("parameter name"))
+		for p.match(Comma) {
+			decl.Parameters = append(decl.Parameters, p.identifier("parameter name"))
+		}
+	}
+	p.expect(RightParenthesis, "right parenthesis after parameters")
+	if p.token.Kind != LeftBrace {
+		p.fail("left brace before " + kind + " body")
+	}
+	decl.Body = p.block()
+	decl.Span = p.span(start)
+	return decl
+}
+
+func (p *parser) varDeclaration() *ast.VarDeclaration {
+	start := p.advance().Start
+	decl := &ast.VarDeclaration{Name: p.identifier("variable name")}
+	if p.match(Equal) {
+		decl.Initializer = p.expression()
+	}
+	p.expect(Semicolon, "semicolon after variable declaration")
+	decl.Span = p.span(start)
+	return decl
+}
+
+func (p *parser) identifier(what string) ast.Identifier {
+	token := p.expect(Identifier, what)
+	return ast.Identifier{Span: ast.Span{Start: token.Start, End: token.End}, Name: p.source[token.Start:token.End]}
+}
+
+func (p *parser) statement() ast.Statement {
+	switch p.token.Kind {
+	case For:
+		return p.forStatement()
+	case If:
+		return p.ifStatement()
+	case Print:
+		start := p.advance().Start
+		expr := p.expression()
+		p.expect(Semicolon, "semicolon after value")
+		return &ast.PrintStatement{Span: p.span(start), Expression: expr}
+	case Return:
+		start := p.advance().Start
+		stmt := &ast.ReturnStatement{}
+		if p.token.Kind != Semicolon {
+			stmt.Expression = p.expression()
+		}
+		p.expect(Semicolon, "semicolon after return value")
+		stmt.Span = p.span(start)
+		return stmt
+	case While:
+		start := p.advance().Start
+		p.expect(LeftParenthesis, "left parenthesis after while")
+		condition := p.expression()
+		p.expect(RightParenthesis, "right parenthesis after condition")
+		body := p.statement()
+		return &ast.WhileStatement{Span: p.span(start), Condition: condition, Body: body}
+	case LeftBrace:
+		return p.block()
+	default:
+		return p.expressionStatement()
+	}
+}
+
+func (p *parser) expressionStatement() *ast.ExpressionStatement {
+	start := p.token.Start
+	expr := p.expression()
+	p.expect(Semicolon, "semicolon after expression")
+	return &ast.ExpressionStatement{Span: p.span(start), Expression: expr}
+}
+
+func (p *parser) forStatement() *ast.ForStatement {
+	start := p.advance().Start
+	stmt := &ast.ForStatement{}
+	p.expect(LeftParenthesis, "left parenthesis after for")
+	switch p.token.Kind {
+	case Semicolon:
+		p.advance()
+	case Var:
+		stmt.Initializer = p.varDeclaration()
+	default:
+		stmt.Initializer = p.expressionStatement()
+	}
+	if p.token.Kind != Semicolon {
+		stmt.Condition = p.expression()
+	}
+	p.expect(Semicolon, "semicolon after loop condition")
+	if p.token.Kind != RightParenthesis {
+		stmt.Increment = p.expression()
+	}
+	p.expect(RightParenthesis, "right parenthesis after for clauses")
+	stmt.Body = p.statement()
+	stmt.Span = p.span(start)
+	return stmt
+}
+
+func (p *parser) ifStatement() *ast.IfStatement {
+	start := p.advance().Start
+	stmt := &ast.IfStatement{}
+	p.expect(LeftParenthesis, "left parenthesis after if")
+	stmt.Condition = p.expression()
+	p.expect(RightParenthesis, "right parenthesis after condition")
+	stmt.Then = p.statement()
+	if p.match(Else) {
+		stmt.Otherwise = p.statement()
+	}
+	stmt.Span = p.span(start)
+	return stmt
+}
+
+// block parses a block starting at the current left brace. Declarations with syntax errors are left out, and stray
+// semicolons are reported and skipped.
+func (p *parser) block() *ast.BlockStatement {
+	start := p.advance().Start
+	stmt := &ast.BlockStatement{}
+	for p.token.Kind != RightBrace && p.token.Kind != EOF {
+		if p.token.Kind == Semicolon {
+			p.reportToken(p.token, syntax.CodeSyntax, "unexpected "+describe(p.token, p.source))
+			p.advance()
+			continue
+		}
+		if decl := p.declaration(); decl != nil {
+			stmt.Declarations = append(stmt.Declarations, decl)
+		}
+	}
+	p.expect(RightBrace, "right brace after block")
+	stmt.Span = p.span(start)
+	return stmt
+}
@@ -0,0 +1,105 @@
+package pratt
+
+import "fmt"
+
+const (
+	EOF TokenKind = iota
+	// Illegal is a character starting no token at all.
+	Illegal
+
+	Identifier
+	String
+	// UnterminatedString is a string literal without the closing quote, which has been reported by the lexer.
+	UnterminatedString
+	Number
+
+	LeftParenthesis
+	RightParenthesis
+	LeftBrace
+	RightBrace
+	Comma
+	Dot
+	Minus
+	Plus
+	Semicolon
+	Slash
+	Star
+	Bang
+	BangEqual
+	Equal
+	EqualEqual
+	Greater
+	GreaterEqual
+	Less
+	LessEqual
+
+	And
+	Class
+	Else
+	False
+	For
+	Fun
+	If
+	Nil
+	Or
+	Print
+	Return
+	Super
+	This
+	True
+	Var
+	While
+)
+
+// TokenKind tells what a [Token] is.
+type TokenKind byte
+
+// Token is a lexical unit of the source code. Its text is not copied, but located by the byte offsets of the source.
+type Token struct {
+	Kind  TokenKind
+	Start int
+	End   int
+}
+
+var keywords = map[string]TokenKind{
+	"and":    And,
+	"class":  Class,
+	"else":   Else,
+	"false":  False,
+	"for":    For,
+	"fun":    Fun,
+	"if":     If,
+	"nil":    Nil,
+	"or":     Or,
+	"print":  Print,
+	"return": Return,
+	"super":  Super,
+	"this":   This,
+	"true":   True,
+	"var":    Var,
+	"while":  While,
+}
+
+// isKeyword tells whether kind is a reserved word.
+func (kind TokenKind) isKeyword() bool { return kind >= And }
+
+// describe names the token t of source in diagnostics, like `keyword "print"` or `end of input`.
+func describe(t Token, source string) string {
+	text := source[t.Start:t.End]
+	switch {
+	case t.Kind == EOF:
+		return "end of input"
+	case t.Kind == Illegal:
+		return fmt.Sprintf("character %q", []rune(text)[0])
+	case t.Kind == Identifier:
+		return fmt.Sprintf("identifier %q", text)
+	case t.Kind == String || t.Kind == UnterminatedString:
+		return "string literal"
+	case t.Kind == Number:
+		return "number " + text
+	case t.Kind.isKeyword():
+		return fmt.Sprintf("keyword %q", text)
+	default:
+		return fmt.Sprintf("token %q", text)
+	}
+}
@@ -0,0 +1,58 @@
+package resolver
+
+import (
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+)
+
+func (r *Resolver) VisitStatementDeclaration(s *ast.StatementDeclaration) {
+	s.Statement.Accept(r)
+}
+
+func (r *Resolver) VisitClass(c *ast.ClassDeclaration) {
+	enclosing := r.class
+	r.class = ordinaryClass
+	defer func() { r.class = enclosing }()
+
+	r.declare(c.Name, classDeclaration)
+	r.define(c.Name.Name)
+
+	if c.Baseclass != nil {
+		if c.Baseclass.Name == c.Name.Name {
+			r.report(c.Baseclass, codeSelfInheritance, "a class cannot inherit from itself").
+				Label(diagnostic.Span(c.Name.Span), "class declared here").
+				Note("the baseclass must be a class declared before")
+		}
+		r.class = subclass
+		r.resolve(c.Baseclass)
+		r.beginScope()
+		r.define("super")
+		defer r.endScope()
+	}
+
+	r.beginScope()
+	r.define("this")
+	for _, method := range c.Methods {
+		kind := methodFunction
+		if method.Name.Name == "init" {
+			kind = initializerFunction
+		}
+		r.resolveFunction(method, kind)
+	}
+	r.endScope()
+}
+
+func (r *Resolver) VisitFun(f *ast.FunDeclaration) {
+	// Functions are defined before their bodies are resolved, so that they can refer to themselves recursively.
+	r.declare(f.Name, functionDeclaration)
+	r.define(f.Name.Name)
+	r.resolveFunction(f, ordinaryFunction)
+}
+
+func (r *Resolver) VisitVar(v *ast.VarDeclaration) {
+	r.declare(v.Name, variableDeclaration)
+	if v.Initializer != nil {
+		v.Initializer.Accept(r)
+	}
+	r.define(v.Name.Name)
+}
@@ -0,0 +1,65 @@
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+)
+
+func (r *Resolver) VisitAssignment(a *ast.AssignmentExpression) {
+	a.Value.Accept(r)
+	a.Target.Accept(r)
+}
+
+func (r *Resolver) VisitBinary(b *ast.BinaryExpression) {
+	b.Left.Accept(r)
+	b.Right.Accept(r)
+}
+
+func (r *Resolver) VisitUnary(u *ast.UnaryExpression) {
+	u.Operand.Accept(r)
+}
+
+func (r *Resolver) VisitInvocation(i *ast.InvocationExpression) {
+	i.Callee.Accept(r)
+	for _, argument := range i.Arguments {
+		argument.Accept(r)
+	}
+}
+
+func (r *Resolver) VisitPropertyAccess(p *ast.PropertyAccessExpression) {
+	p.Target.Accept(r)
+}
+
+func (r *Resolver) VisitThis(t ast.This) {
+	if r.class == noClass {
+		r.report(t, codeThisOutsideClass, "cannot use this outside of a class")
+	}
+}
+
+func (r *Resolver) VisitSuper(s ast.Super) {
+	switch r.class {
+	case noClass:
+		r.report(s, codeSuperOutsideClass, "cannot use super outside of a class")
+	case ordinaryClass:
+		r.report(s, codeSuperWithoutBaseclass, "cannot use super in a class with no baseclass").
+			Help("declare a baseclass after the class name, like `class Derived < Base`")
+	}
+}
+
+func (r *Resolver) VisitIdentifier(i *ast.Identifier) {
+	if len(r.scopes) > 0 {
+		if v, exists := r.scopes[len(r.scopes)-1].variables[i.Name]; exists && !v.ready {
+			r.report(i, codeSelfReferencing, fmt.Sprintf("cannot read local variable %s in its own initializer", i.Name)).
+				Label(diagnostic.Span(v.name), "declared here").
+				Note(fmt.Sprintf("the new %s shadows any outer variable with the same name from its declaration on", i.Name))
+		}
+	}
+	r.resolve(i)
+}
+
+func (r *Resolver) VisitBooleanLiteral(ast.BooleanLiteral) {}
+func (r *Resolver) VisitNil(ast.Nil)                       {}
+func (r *Resolver) VisitNumberLiteral(ast.NumberLiteral)   {}
+func (r *Resolver) VisitStringLiteral(ast.StringLiteral)   {}
@@ -0,0 +1,212 @@
+// Package resolver checks the scoping rules of Lox, and binds every variable reference to the scope declaring it.
+//
+// The resolution is done statically before code generation, so that semantic errors are reported without running the
+// program, and the code generator knows whether a variable is a local, an upvalue or a global.
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+)
+
+const (
+	Global Kind = iota
+	Local
+	Upvalue
+)
+
+// Codes of the diagnostics reported by the resolver, set by [diagnostic.Diagnostic.WithCode].
+const (
+	codeSelfInheritance          = "self-inheritance"
+	codeThisOutsideClass         = "this-outside-class"
+	codeSuperOutsideClass        = "super-outside-class"
+	codeSuperWithoutBaseclass    = "super-without-baseclass"
+	codeSelfReferencing          = "self-referencing-initializer"
+	codeRedeclaration            = "redeclaration"
+	codeReturnOutsideFunction    = "return-outside-function"
+	codeReturnValueInInitializer = "return-value-in-initializer"
+)
+
+// Kinds of declarations, which name the declared variable in diagnostics.
+const (
+	variableDeclaration  = "variable"
+	parameterDeclaration = "parameter"
+	functionDeclaration  = "function"
+	classDeclaration     = "class"
+)
+
+const (
+	noFunction functionKind = iota
+	ordinaryFunction
+	methodFunction
+	initializerFunction
+)
+
+const (
+	noClass classKind = iota
+	ordinaryClass
+	subclass
+)
+
+// Kind tells where a variable lives at runtime.
+type Kind int
+
+type functionKind int
+type classKind int
+
+// Binding is the result of resolving a variable reference.
+type Binding struct {
+	// Kind is Local if the variable is declared in the same function as the reference, Upvalue if it is declared in an
+	// enclosing function, or Global if it is not declared in any scope.
+	Kind Kind
+}
+
+// Resolution holds the bindings of all variable references in a program.
+type Resolution struct {
+	bindings map[*ast.Identifier]Binding
+}
+
+// Lookup returns the [Binding] of a variable reference. References which are never resolved are globals.
+func (r *Resolution) Lookup(reference *ast.Identifier) Binding {
+	if binding, exists := r.bindings[reference]; exists {
+		return binding
+	}
+	return Binding{Kind: Global}
+}
+
+// Resolver walks the AST, implementing [ast.DeclarationVisitor], [ast.StatementVisitor] and [ast.ExpressionVisitor].
+type Resolver struct {
+	source      *diagnostic.Source
+	resolution  *Resolution
+	scopes      []scope
+	function    functionKind
+	class       classKind
+	diagnostics diagnostic.List
+}
+
+// scope maps names declared in a block to their variables. Scopes remember the function they belong to, telling locals
+// and upvalues apart.
+type scope struct {
+	variables map[string]variable
+	function  int
+}
+
+// variable is a local variable declared at name, which is ready to be read once its initializer is finished.
+type variable struct {
+	name  ast.Span
+	ready bool
+}
+
+// Resolve checks the program parsed from source, and returns the bindings of its variable references.
+func Resolve(program []ast.Declaration, source *diagnostic.Source) (*Resolution, error) {
+	r := &Resolver{
+		source:     source,
+		resolution: &Resolution{bindings: make(map[*ast.Identifier]Binding)},
+	}
+	for _, decl := range program {
+		decl.Accept(r)
+	}
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	return r.resolution, nil
+}
+
+// Err returns all diagnostics reported so far as a [diagnostic.List], or nil if there is none.
+func (r *Resolver) Err() error { return r.diagnostics.Err() }
+
+// report adds an error of code at node, returning it so that labels and notes can be attached.
+func (r *Resolver) report(node ast.Node, code, message string) *diagnostic.Diagnostic {
+	diag := diagnostic.NewDiagnostic(message).WithCode(code).AtSpan(diagnostic.Span(node.Location())).Attach(r.source)
+	r.diagnostics.Add(diag)
+	return diag
+}
+
+// functionDepth counts the functions enclosing the current scope.
+func (r *Resolver) functionDepth() int {
+	if len(r.scopes) == 0 {
+		return 0
+	}
+	return r.scopes[len(r.scopes)-1].function
+}
+
+func (r *Resolver) beginScope() {
+	r.scopes = append(r.scopes, scope{
+		variables: make(map[string]variable),
+		function:  r.functionDepth(),
+	})
+}
+
+// beginFunctionScope begins the outermost scope of a function body, where its parameters live.
+func (r *Resolver) beginFunctionScope() {
+	r.scopes = append(r.scopes, scope{
+		variables: make(map[string]variable),
+		function:  r.functionDepth() + 1,
+	})
+}
+
+func (r *Resolver) endScope() { r.scopes = r.scopes[:len(r.scopes)-1] }
+
+// declare adds name into the innermost scope, marking it not ready to be read. Globals are not tracked, since Lox
+// allows redeclaring them. The kind of declaration is one of the declaration kinds, which words the redeclaration error.
+func (r *Resolver) declare(name ast.Identifier, kind string) {
+	if len(r.scopes) == 0 {
+		return
+	}
+	variables := r.scopes[len(r.scopes)-1].variables
+	if previous, exists := variables[name.Name]; exists {
+		help := fmt.Sprintf("give the %s another name", kind)
+		if kind == variableDeclaration {
+			// Only a variable can be turned into an assignment.
+			help = fmt.Sprintf("assign to %s without var, or give the new variable another name", name.Name)
+		}
+		r.report(name, codeRedeclaration, fmt.Sprintf("%s %s is already declared in this scope", kind, name.Name)).
+			Label(diagnostic.Span(previous.name), "previously declared here").
+			Help(help)
+	}
+	variables[name.Name] = variable{name: name.Span}
+}
+
+// define marks name in the innermost scope ready to be read.
+func (r *Resolver) define(name string) {
+	if len(r.scopes) == 0 {
+		return
+	}
+	variables := r.scopes[len(r.scopes)-1].variables
+	v := variables[name]
+	v.ready = true
+	variables[name] = v
+}
+
+// resolve binds reference to the innermost scope declaring it.
+func (r *Resolver) resolve(reference *ast.Identifier) {
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if _, exists := r.scopes[i].variables[reference.Name]; !exists {
+			continue
+		}
+		binding := Binding{Kind: Local}
+		if r.scopes[i].function != r.functionDepth() {
+			binding.Kind = Upvalue
+		}
+		r.resolution.bindings[reference] = binding
+		return
+	}
+}
+
+func (r *Resolver) resolveFunction(f *ast.FunDeclaration, kind functionKind) {
+	enclosing := r.function
+	r.function = kind
+	defer func() { r.function = enclosing }()
+
+	r.beginFunctionScope()
+	for _, parameter := range f.Parameters {
+		r.declare(parameter, parameterDeclaration)
+		r.define(parameter.Name)
+	}
+	for _, decl := range f.Body.Declarations {
+		decl.Accept(r)
+	}
+	r.endScope()
+}
@@ -0,0 +1,229 @@
+package resolver_test
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mussel-lox/clam/ast"
+	"github.com/mussel-lox/clam/internal/diagnostic"
+	"github.com/mussel-lox/clam/parser"
+	"github.com/mussel-lox/clam/resolver"
+)
+
+// resolve parses source, failing the test on syntax errors, and resolves it.
+func resolve(t *testing.T, source string) ([]ast.Declaration, *resolver.Resolution, error) {
+	t.Helper()
+	program, err := parser.Parse("test.lox", source)
+	if err != nil {
+		t.Fatalf("syntax errors: %v", err)
+	}
+	resolution, err := resolver.Resolve(program, diagnostic.NewSource("test.lox", source))
+	return program, resolution, err
+}
+
+// referencesOf returns all variable references in node, in the order of the source.
+func referencesOf(node any) []*ast.Identifier {
+	var references []*ast.Identifier
+	var walk func(value reflect.Value)
+	walk = func(value reflect.Value) {
+		switch value.Kind() {
+		case reflect.Pointer, reflect.Interface:
+			if value.IsNil() {
+				return
+			}
+			if reference, isReference := value.Interface().(*ast.Identifier); isReference {
+				references = append(references, reference)
+				return
+			}
+			walk(value.Elem())
+		case reflect.Slice:
+			for i := range value.Len() {
+				walk(value.Index(i))
+			}
+		case reflect.Struct:
+			for i := range value.NumField() {
+				walk(value.Field(i))
+			}
+		}
+	}
+	walk(reflect.ValueOf(node))
+	slices.SortFunc(references, func(a, b *ast.Identifier) int { return a.Start - b.Start })
+	return references
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		code   string
+		// blamed is the text covered by the diagnostic, at its last occurrence in source.
+		blamed string
+	}{
+		{
+			name:   "self-referencing initializer",
+			source: "var a = 1;\n{ var a = a + 1; }",
+			code:   "self-referencing-initializer",
+			blamed: "a",
+		},
+		{
+			name:   "redeclaration",
+			source: "fun f() {\n  var a = 1;\n  var a = 2;\n}",
+			code:   "redeclaration",
+			blamed: "a",
+		},
+		{
+			name:   "return outside function",
+			source: "if (true) return 1;",
+			code:   "return-outside-function",
+			blamed: "return 1;",
+		},
+		{
+			name:   "return value in initializer",
+			source: "class A {\n  init() { return 1; }\n}",
+			code:   "return-value-in-initializer",
+			blamed: "1",
+		},
+		{
+			name:   "this outside class",
+			source: "fun f() { print this; }",
+			code:   "this-outside-class",
+			blamed: "this",
+		},
+		{
+			name:   "super outside class",
+			source: "fun f() { super.m(); }",
+			code:   "super-outside-class",
+			blamed: "super",
+		},
+		{
+			name:   "super without baseclass",
+			source: "class A {\n  m() { return super.m(); }\n}",
+			code:   "super-without-baseclass",
+			blamed: "super",
+		},
+		{
+			name:   "self-inheritance",
+			source: "class A < A {}",
+			code:   "self-inheritance",
+			blamed: "A",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := resolve(t, test.source)
+			var diagnostics diagnostic.List
+			if !errors.As(err, &diagnostics) {
+				t.Fatalf("got error %v, want diagnostics", err)
+			}
+			if len(diagnostics) != 1 {
+				t.Fatalf("got %d diagnostics, want 1: %v", len(diagnostics), err)
+			}
+			d := diagnostics[0]
+			if d.Code() != test.code {
+				t.Errorf("got code %q, want %q", d.Code(), test.code)
+			}
+			start := strings.LastIndex(test.source, test.blamed)
+			want := diagnostic.Span{Start: start, End: start + len(test.blamed)}
+			if span, _ := d.Span(); span != want {
+				t.Errorf("got span %v, want %v", span, want)
+			}
+		})
+	}
+}
+
+func TestBindings(t *testing.T) {
+	kindNames := map[resolver.Kind]string{
+		resolver.Global:  "global",
+		resolver.Local:   "local",
+		resolver.Upvalue: "upvalue",
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		// want are the names and binding kinds of all variable references, in the order of the source.
+		want []string
+	}{
+		{
+			name:   "globals",
+			source: "var a = 1;\nprint a + b;",
+			want:   []string{"a global", "b global"},
+		},
+		{
+			name:   "locals",
+			source: "var a = 1;\n{ var a = 2; { print a; } }\nprint a;",
+			want:   []string{"a local", "a global"},
+		},
+		{
+			name:   "parameters",
+			source: "fun f(a) { print a; return f; }",
+			want:   []string{"a local", "f global"},
+		},
+		{
+			name:   "upvalues",
+			source: "fun f(a) {\n  var b;\n  fun g() {\n    fun h() { print a + b + c; }\n    return h;\n  }\n}",
+			want:   []string{"a upvalue", "b upvalue", "c global", "h local"},
+		},
+		{
+			// A class declared in a function is a local of the function, captured by its own methods.
+			name:   "class in function",
+			source: "fun f() {\n  class A { m() { return A; } }\n  class B < A {}\n  return B;\n}",
+			want:   []string{"A upvalue", "A local", "B local"},
+		},
+		{
+			name:   "for loop",
+			source: "for (var i = 0; i < 1; i = i + 1) { fun f() { print i; } }",
+			want:   []string{"i local", "i local", "i local", "i upvalue"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			program, resolution, err := resolve(t, test.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, reference := range referencesOf(program) {
+				got = append(got, reference.Name+" "+kindNames[resolution.Lookup(reference).Kind])
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRedeclarationHelp(t *testing.T) {
+	tests := []struct {
+		source  string
+		message string
+		help    string
+	}{
+		{"{ var a; var a; }", "variable a is already declared in this scope", "assign to a without var, or give the new variable another name"},
+		{"fun f(a) { var a; }", "variable a is already declared in this scope", "assign to a without var, or give the new variable another name"},
+		{"fun f(a, a) {}", "parameter a is already declared in this scope", "give the parameter another name"},
+		{"{ var a; fun a() {} }", "function a is already declared in this scope", "give the function another name"},
+		{"{ fun a() {} class a {} }", "class a is already declared in this scope", "give the class another name"},
+	}
+	for _, test := range tests {
+		_, _, err := resolve(t, test.source)
+		var diagnostics diagnostic.List
+		if !errors.As(err, &diagnostics) || len(diagnostics) != 1 {
+			t.Errorf("%q: got %v, want one redeclaration", test.source, err)
+			continue
+		}
+		d := diagnostics[0]
+		if d.Message() != test.message {
+			t.Errorf("%q: got message %q, want %q", test.source, d.Message(), test.message)
+		}
+		attachments := d.Attachments()
+		if len(attachments) != 1 || attachments[0].Severity != diagnostic.Help || attachments[0].Message != test.help {
+			t.Errorf("%q: got attachments %v, want help %q", test.source, attachments, test.help)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package resolver
+
+import "github.com/mussel-lox/clam/ast"
+
+func (r *Resolver) VisitExpressionStatement(es *ast.ExpressionStatement) {
+	es.Expression.Accept(r)
+}
+
+func (r *Resolver) VisitFor(f *ast.ForStatement) {
+	r.beginScope()
+	defer r.endScope()
+
+	switch initializer := f.Initializer.(type) {
+	case *ast.VarDeclaration:
+		initializer.Accept(r)
+	case *ast.ExpressionStatement:
+		initializer.Accept(r)
+	}
+	if f.Condition != nil {
+		f.Condition.Accept(r)
+	}
+	if f.Increment != nil {
+		f.Increment.Accept(r)
+	}
+	f.Body.Accept(r)
+}
+
+func (r *Resolver) VisitIf(i *ast.IfStatement) {
+	i.Condition.Accept(r)
+	i.Then.Accept(r)
+	if i.Otherwise != nil {
+		i.Otherwise.Accept(r)
+	}
+}
+
+func (r *Resolver) VisitPrint(p *ast.PrintStatement) {
+	p.Expression.Accept(r)
+}
+
+func (r *Resolver) VisitReturn(ret *ast.ReturnStatement) {
+	if r.function == noFunction {
+		r.report(ret, codeReturnOutsideFunction, "cannot return from top-level code")
+	}
+	if ret.Expression == nil {
+		return
+	}
+	if r.function == initializerFunction {
+		r.report(ret.Expression, codeReturnValueInInitializer, "cannot return a value from an initializer")
+	}
+	ret.Expression.Accept(r)
+}
+
+func (r *Resolver) VisitWhile(w *ast.WhileStatement) {
+	w.Condition.Accept(r)
+	w.Body.Accept(r)
+}
+
+func (r *Resolver) VisitBlock(b *ast.BlockStatement) {
+	r.beginScope()
+	for _, decl := range b.Declarations {
+		decl.Accept(r)
+	}
+	r.endScope()
+}